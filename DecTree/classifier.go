@@ -0,0 +1,145 @@
+package DecTree
+
+import (
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"slices"
+)
+
+// DecTreeClassifier is a classification tree built with the same machinery as DecTree, choosing splits by the
+// decrease in Gini impurity or entropy of the class labels. Leaves hold the class distribution of their rows.
+// The embedded Criterion is Gini or Entropy.
+type DecTreeClassifier struct {
+	DecTree
+	Classes []float64 `json:"classes"`
+
+	ClassificationMetrics metrics.ClassificationMetrics
+
+	targets []int // Index of each row's label in Classes
+}
+
+func NewDecTreeClassifier(x [][]float64, y []float64, maxDepth, minSamplesSplit, minSamplesLeaf int, criterion string, randomSeed *int64, maxFeatures *int) Ensemble.Estimator {
+	classCriterion, ok := map[string]ClassCriterion{"gini": Gini{}, "entropy": Entropy{}}[criterion]
+	if !ok {
+		panic(`Criterion must be "gini" or "entropy"`)
+	}
+
+	tree := NewDecTree(x, y, maxDepth, minSamplesSplit, minSamplesLeaf, randomSeed, maxFeatures).(*DecTree)
+	dtc := &DecTreeClassifier{
+		DecTree: *tree,
+		Classes: metrics.UniqueClasses(tree.Y),
+	}
+	dtc.Criterion = classCriterion
+	dtc.targets = make([]int, len(dtc.Y))
+	for i, label := range dtc.Y {
+		dtc.targets[i], _ = slices.BinarySearch(dtc.Classes, label)
+	}
+	dtc.impurity = dtc.classImpurity
+	dtc.newLeaf = dtc.classLeaf
+	dtc.sweep = dtc.classSweep
+	return dtc
+}
+
+func NewDefaultDecTreeClassifier(x [][]float64, y []float64) Ensemble.Estimator {
+	return NewDecTreeClassifier(x, y, 10, 2, 1, "gini", nil, nil)
+}
+
+// classDistribution returns the share of each class among the given rows.
+func (dtc *DecTreeClassifier) classDistribution(indices []int) []float64 {
+	distribution := make([]float64, len(dtc.Classes))
+	for _, idx := range indices {
+		distribution[dtc.targets[idx]]++
+	}
+	for k := range distribution {
+		distribution[k] /= float64(len(indices))
+	}
+	return distribution
+}
+
+func (dtc *DecTreeClassifier) classImpurity(indices []int) float64 {
+	if len(indices) == 0 {
+		return 0.0
+	}
+
+	return dtc.Criterion.(ClassCriterion).ImpurityFromShares(dtc.classDistribution(indices))
+}
+
+// classSweep keeps running class counts of the left child to score every threshold of rows sorted by a feature.
+func (dtc *DecTreeClassifier) classSweep(rows []int) []float64 {
+	criterion := dtc.Criterion.(ClassCriterion)
+	n := float64(len(rows))
+
+	total := make([]float64, len(dtc.Classes))
+	for _, idx := range rows {
+		total[dtc.targets[idx]]++
+	}
+	shares := make([]float64, len(dtc.Classes))
+	for k := range shares {
+		shares[k] = total[k] / n
+	}
+	parent := criterion.ImpurityFromShares(shares)
+
+	scores := make([]float64, len(rows)-1)
+	left := make([]float64, len(dtc.Classes))
+	for i := range scores {
+		left[dtc.targets[rows[i]]]++
+		nLeft := float64(i + 1)
+		nRight := n - nLeft
+
+		for k := range shares {
+			shares[k] = left[k] / nLeft
+		}
+		leftImpurity := criterion.ImpurityFromShares(shares)
+		for k := range shares {
+			shares[k] = (total[k] - left[k]) / nRight
+		}
+		rightImpurity := criterion.ImpurityFromShares(shares)
+
+		scores[i] = parent - (nLeft*leftImpurity+nRight*rightImpurity)/n
+	}
+	return scores
+}
+
+// classLeaf stores the class distribution of the leaf, with the most frequent class as its value.
+func (dtc *DecTreeClassifier) classLeaf(indices []int) *Node {
+	distribution := dtc.classDistribution(indices)
+	return &Node{
+		value:        dtc.Classes[metrics.ArgMax(distribution)],
+		distribution: distribution,
+		isLeaf:       true,
+	}
+}
+
+func (dtc *DecTreeClassifier) Fit() {
+	dtc.grow()
+
+	preds := make([]float64, len(dtc.Y))
+	proba := make([][]float64, len(dtc.Y))
+	for i, row := range dtc.X {
+		proba[i] = dtc.PredictProba(row)
+		preds[i] = dtc.PredictClass(row)
+	}
+	dtc.Metrics = metrics.Evaluate(dtc.Y, preds)
+	dtc.ClassificationMetrics = metrics.EvaluateClassifier(dtc.Y, preds, proba, dtc.Classes)
+}
+
+// PredictProba returns the class distribution of the leaf x falls into, in GetClasses order.
+func (dtc *DecTreeClassifier) PredictProba(x []float64) []float64 {
+	return slices.Clone(dtc.leaf(x).distribution)
+}
+
+func (dtc *DecTreeClassifier) PredictClass(x []float64) float64 {
+	return dtc.leaf(x).value
+}
+
+func (dtc *DecTreeClassifier) Predict(x []float64) float64 {
+	return dtc.PredictClass(x)
+}
+
+func (dtc *DecTreeClassifier) GetClasses() []float64 {
+	return dtc.Classes
+}
+
+func (dtc *DecTreeClassifier) GetClassificationMetrics() metrics.ClassificationMetrics {
+	return dtc.ClassificationMetrics
+}
@@ -0,0 +1,80 @@
+package DecTree
+
+import (
+	"GoML/metrics"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// thirds draws a class from the third of [0, 1] the first feature falls in, keeping clear of the boundaries, and a
+// second feature of pure noise.
+func thirds(rng *rand.Rand, nRows int) ([][]float64, []float64) {
+	x := make([][]float64, 0, nRows)
+	y := make([]float64, 0, nRows)
+	for len(x) < nRows {
+		x0 := rng.Float64()
+		if class := math.Floor(3 * x0); math.Abs(3*x0-math.Round(3*x0)) > 0.05 {
+			x = append(x, []float64{x0, rng.Float64()})
+			y = append(y, class)
+		}
+	}
+	return x, y
+}
+
+func TestClassifierSeparable(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	x, y := thirds(rng, 200)
+	for _, criterion := range []string{"gini", "entropy"} {
+		t.Run(criterion, func(t *testing.T) {
+			seed := int64(1)
+			dtc := NewDecTreeClassifier(x, y, 10, 2, 1, criterion, &seed, nil).(*DecTreeClassifier)
+			dtc.Fit()
+			if !slices.Equal(dtc.Classes, []float64{0, 1, 2}) {
+				t.Fatalf("classes %v", dtc.Classes)
+			}
+			if dtc.ClassificationMetrics.Accuracy != 1 {
+				t.Errorf("training accuracy %v, want 1", dtc.ClassificationMetrics.Accuracy)
+			}
+
+			xTest, yTest := thirds(rng, 200)
+			for i, row := range xTest {
+				// The leaves are pure, so the probabilities are one-hot on the class
+				proba := dtc.PredictProba(row)
+				want := make([]float64, 3)
+				want[int(yTest[i])] = 1
+				if !slices.Equal(proba, want) {
+					t.Fatalf("PredictProba(%v) = %v, want %v", row, proba, want)
+				}
+				if got := dtc.PredictClass(row); got != yTest[i] {
+					t.Fatalf("PredictClass(%v) = %v, want %v", row, got, yTest[i])
+				}
+			}
+		})
+	}
+}
+
+// A tree of depth zero predicts the class shares of all the rows and the most frequent class, the first on ties.
+func TestClassifierLeafDistribution(t *testing.T) {
+	x := [][]float64{{0}, {1}, {2}, {3}, {4}, {5}}
+	for _, tc := range []struct {
+		y         []float64
+		wantProba []float64
+		wantClass float64
+	}{
+		{[]float64{2, 5, 5, 7, 5, 2}, []float64{2.0 / 6, 3.0 / 6, 1.0 / 6}, 5},
+		{[]float64{7, 2, 7, 2, 7, 2}, []float64{0.5, 0.5}, 2},
+	} {
+		seed := int64(1)
+		dtc := NewDecTreeClassifier(x, tc.y, 0, 2, 1, "gini", &seed, nil).(*DecTreeClassifier)
+		dtc.Fit()
+		proba := dtc.PredictProba([]float64{2.5})
+		if !slices.EqualFunc(proba, tc.wantProba, func(a, b float64) bool { return math.Abs(a-b) < 1e-15 }) {
+			t.Errorf("y %v: PredictProba %v, want %v", tc.y, proba, tc.wantProba)
+		}
+		if got := dtc.PredictClass([]float64{2.5}); got != tc.wantClass || got != dtc.Classes[metrics.ArgMax(proba)] {
+			t.Errorf("y %v: PredictClass %v, want %v", tc.y, got, tc.wantClass)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package DecTree
+
+import (
+	"GoML/metrics"
+	"errors"
+	"math"
+	"slices"
+)
+
+// Criterion measures the impurity of a tree node. Splits are chosen to maximize the impurity of the parent minus
+// the size weighted impurity of the children, and leaves predict the value that minimizes the criterion.
+type Criterion interface {
+	Name() string
+	// Impurity returns the impurity of a node holding the targets y[indices].
+	Impurity(y []float64, indices []int) float64
+	// LeafValue returns the prediction of a leaf holding the targets y[indices].
+	LeafValue(y []float64, indices []int) float64
+}
+
+// SplitScorer is implemented by criteria that score splits with something other than the impurity decrease.
+type SplitScorer interface {
+	SplitScore(y []float64, leftIdx, rightIdx []int) float64
+}
+
+// ClassCriterion is a Criterion over class labels that can also score a node from the share of each class.
+type ClassCriterion interface {
+	Criterion
+	ImpurityFromShares(shares []float64) float64
+}
+
+// MSE is the mean squared error around the node mean, i.e. variance reduction. It is the default criterion.
+type MSE struct{}
+
+// MAE is the mean absolute error around the node median. Leaves predict the median, which makes the tree far less
+// sensitive to heavy-tailed targets than MSE at the cost of slower fitting.
+type MAE struct{}
+
+// FriedmanMSE uses the MSE impurity but scores splits with Friedman's improvement
+// nLeft * nRight / (nLeft + nRight) * (meanLeft - meanRight)^2, as in gradient boosting.
+type FriedmanMSE struct{}
+
+// Poisson is the half Poisson deviance around the node mean, for non-negative count or rate targets.
+// Splits leaving a child with a zero mean are never chosen since the deviance of any positive prediction is infinite.
+type Poisson struct{}
+
+// Gini is the Gini impurity of the class labels.
+type Gini struct{}
+
+// Entropy is the Shannon entropy of the class labels, in bits.
+type Entropy struct{}
+
+// CriterionByName returns the criterion with the given name. Both the scikit-learn names and the usual
+// abbreviations are accepted: "squared_error" / "mse", "absolute_error" / "mae", "friedman_mse", "poisson",
+// "gini" and "entropy".
+func CriterionByName(name string) (Criterion, error) {
+	switch name {
+	case "squared_error", "mse":
+		return MSE{}, nil
+	case "absolute_error", "mae":
+		return MAE{}, nil
+	case "friedman_mse":
+		return FriedmanMSE{}, nil
+	case "poisson":
+		return Poisson{}, nil
+	case "gini":
+		return Gini{}, nil
+	case "entropy":
+		return Entropy{}, nil
+	default:
+		return nil, errors.New("unsupported criterion")
+	}
+}
+
+func meanSubset(y []float64, indices []int) float64 {
+	if len(indices) == 0 {
+		return 0.0
+	}
+	sum := 0.0
+	for _, idx := range indices {
+		sum += y[idx]
+	}
+	return sum / float64(len(indices))
+}
+
+func medianSubset(y []float64, indices []int) float64 {
+	if len(indices) == 0 {
+		return 0.0
+	}
+	values := make([]float64, len(indices))
+	for i, idx := range indices {
+		values[i] = y[idx]
+	}
+	slices.Sort(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+func (MSE) Name() string { return "squared_error" }
+
+func (MSE) Impurity(y []float64, indices []int) float64 {
+	return varianceSubset(y, indices)
+}
+
+func (MSE) LeafValue(y []float64, indices []int) float64 {
+	return meanSubset(y, indices)
+}
+
+func (MAE) Name() string { return "absolute_error" }
+
+func (MAE) Impurity(y []float64, indices []int) float64 {
+	if len(indices) == 0 {
+		return 0.0
+	}
+	median := medianSubset(y, indices)
+	sum := 0.0
+	for _, idx := range indices {
+		sum += math.Abs(y[idx] - median)
+	}
+	return sum / float64(len(indices))
+}
+
+func (MAE) LeafValue(y []float64, indices []int) float64 {
+	return medianSubset(y, indices)
+}
+
+func (FriedmanMSE) Name() string { return "friedman_mse" }
+
+func (FriedmanMSE) Impurity(y []float64, indices []int) float64 {
+	return varianceSubset(y, indices)
+}
+
+func (FriedmanMSE) LeafValue(y []float64, indices []int) float64 {
+	return meanSubset(y, indices)
+}
+
+func (FriedmanMSE) SplitScore(y []float64, leftIdx, rightIdx []int) float64 {
+	nLeft, nRight := float64(len(leftIdx)), float64(len(rightIdx))
+	diff := meanSubset(y, leftIdx) - meanSubset(y, rightIdx)
+	return nLeft * nRight / (nLeft + nRight) * diff * diff
+}
+
+func (Poisson) Name() string { return "poisson" }
+
+func (Poisson) Impurity(y []float64, indices []int) float64 {
+	if len(indices) == 0 {
+		return 0.0
+	}
+	mean := meanSubset(y, indices)
+	if mean <= 0 {
+		return math.Inf(1)
+	}
+	deviance := 0.0
+	for _, idx := range indices {
+		if y[idx] > 0 {
+			deviance += y[idx] * math.Log(y[idx]/mean)
+		}
+		deviance += mean - y[idx]
+	}
+	return deviance / float64(len(indices))
+}
+
+func (Poisson) LeafValue(y []float64, indices []int) float64 {
+	return meanSubset(y, indices)
+}
+
+// labelShares returns the sorted labels of y[indices] and the share of the rows holding each of them.
+func labelShares(y []float64, indices []int) (labels, shares []float64) {
+	counts := make(map[float64]float64)
+	for _, idx := range indices {
+		counts[y[idx]]++
+	}
+	labels = make([]float64, 0, len(counts))
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	slices.Sort(labels)
+	shares = make([]float64, len(labels))
+	for k, label := range labels {
+		shares[k] = counts[label] / float64(len(indices))
+	}
+	return labels, shares
+}
+
+// majorityLabel is the most frequent label of y[indices], the smallest one on ties.
+func majorityLabel(y []float64, indices []int) float64 {
+	labels, shares := labelShares(y, indices)
+	if len(labels) == 0 {
+		return 0.0
+	}
+	return labels[metrics.ArgMax(shares)]
+}
+
+func (Gini) Name() string { return "gini" }
+
+func (g Gini) Impurity(y []float64, indices []int) float64 {
+	if len(indices) == 0 {
+		return 0.0
+	}
+	_, shares := labelShares(y, indices)
+	return g.ImpurityFromShares(shares)
+}
+
+func (Gini) ImpurityFromShares(shares []float64) float64 {
+	impurity := 1.0
+	for _, p := range shares {
+		impurity -= p * p
+	}
+	return impurity
+}
+
+func (Gini) LeafValue(y []float64, indices []int) float64 {
+	return majorityLabel(y, indices)
+}
+
+func (Entropy) Name() string { return "entropy" }
+
+func (e Entropy) Impurity(y []float64, indices []int) float64 {
+	if len(indices) == 0 {
+		return 0.0
+	}
+	_, shares := labelShares(y, indices)
+	return e.ImpurityFromShares(shares)
+}
+
+func (Entropy) ImpurityFromShares(shares []float64) float64 {
+	impurity := 0.0
+	for _, p := range shares {
+		if p > 0 {
+			impurity -= p * math.Log2(p)
+		}
+	}
+	return impurity
+}
+
+func (Entropy) LeafValue(y []float64, indices []int) float64 {
+	return majorityLabel(y, indices)
+}
@@ -4,7 +4,6 @@ import (
 	"GoML/Ensemble"
 	"GoML/metrics"
 	"fmt"
-	"math"
 	"math/rand"
 	"slices"
 	"strings"
@@ -16,7 +15,13 @@ type Node struct {
 	threshold    float64
 	left, right  *Node
 	value        float64
+	samples      []float64 // Sorted leaf targets, only stored in quantile-leaf mode
+	distribution []float64 // Class probabilities, only stored by classification trees
 	isLeaf       bool
+	leafIndex    int // Order in which the leaf was grown
+
+	nSamples int     // Training rows reaching the node
+	impurity float64 // Criterion impurity of those rows
 }
 
 type DecTree struct {
@@ -24,12 +29,32 @@ type DecTree struct {
 	Y               []float64   `json:"y"`
 	Metrics         metrics.Metrics
 	root            *Node
+	nLeaves         int
 	MaxDepth        int    `json:"max_depth"`
 	MinSamplesSplit int    `json:"min_samples_split"`
 	MinSamplesLeaf  int    `json:"min_samples_leaf"`
 	MaxFeatures     *int   `json:"max_features"`
 	RandomSeed      *int64 `json:"random_seed"`
 	rng             *rand.Rand
+
+	// Criterion scores the candidate splits and sets the leaf values. Nil means MSE.
+	Criterion Criterion
+
+	// RandomSplits grows an extremely randomized tree: each candidate feature gets a single threshold drawn
+	// uniformly between its smallest and largest value in the node, and the best of those splits is kept.
+	RandomSplits bool `json:"random_splits"`
+
+	// Quantile-leaf mode: leaves keep the empirical distribution of their targets and Predict returns
+	// its Quantile-th quantile instead of the mean. PredictQuantile serves any other quantile.
+	QuantileLeaves bool    `json:"quantile_leaves"`
+	Quantile       float64 `json:"quantile"`
+
+	// Hooks that let tree variants (e.g. DecTreeClassifier) reuse the tree building machinery.
+	// A nil impurity is the Criterion impurity of Y and a nil newLeaf stores the Criterion leaf value of Y.
+	// sweep scores every threshold of rows sorted by a feature (see sweeper) and falls back to the Criterion.
+	impurity func(indices []int) float64
+	newLeaf  func(indices []int) *Node
+	sweep    func(rows []int) []float64
 }
 
 func NewDecTree(x [][]float64, y []float64, maxDepth, minSamplesSplit, minSamplesLeaf int, randomSeed *int64, maxFeatures *int) Ensemble.Estimator {
@@ -63,103 +88,94 @@ func NewDefaultDecTree(x [][]float64, y []float64) Ensemble.Estimator {
 	return NewDecTree(x, y, 10, 2, 1, nil, nil)
 }
 
+// NewQuantileDecTree creates a decision tree in quantile-leaf mode, predicting the given quantile of the targets
+// that share a leaf. Splits are still chosen by the Criterion, variance reduction by default.
+func NewQuantileDecTree(x [][]float64, y []float64, maxDepth, minSamplesSplit, minSamplesLeaf int, quantile float64, randomSeed *int64, maxFeatures *int) Ensemble.Estimator {
+	if quantile < 0 || quantile > 1 {
+		panic("Quantile must be in [0, 1]")
+	}
+	dt := NewDecTree(x, y, maxDepth, minSamplesSplit, minSamplesLeaf, randomSeed, maxFeatures).(*DecTree)
+	dt.QuantileLeaves = true
+	dt.Quantile = quantile
+	return dt
+}
+
 func (dt *DecTree) createLeaf(indices []int) *Node {
-	sum := 0.0
-	for _, idx := range indices {
-		sum += dt.Y[idx]
+	if dt.newLeaf != nil {
+		return dt.newLeaf(indices)
 	}
-	return &Node{
-		value:  sum / float64(len(indices)),
+
+	node := &Node{
+		value:  dt.criterion().LeafValue(dt.Y, indices),
 		isLeaf: true,
 	}
-}
-
-func (dt *DecTree) splitIndices(indices []int, featureIdx int, threshold float64) (leftIdx, rightIdx []int) {
-	for _, idx := range indices {
-		if dt.X[idx][featureIdx] <= threshold {
-			leftIdx = append(leftIdx, idx)
-		} else {
-			rightIdx = append(rightIdx, idx)
+	if dt.QuantileLeaves {
+		node.samples = make([]float64, len(indices))
+		for i, idx := range indices {
+			node.samples[i] = dt.Y[idx]
 		}
+		slices.Sort(node.samples)
 	}
-	return leftIdx, rightIdx
+	return node
 }
 
-func (dt *DecTree) varianceReduction(y []float64, leftIdx, rightIdx []int) float64 {
-	totVar := varianceSubset(y, append(leftIdx, rightIdx...))
-	leftVar := varianceSubset(y, leftIdx)
-	rightVar := varianceSubset(y, rightIdx)
-
-	weightedVar := (float64(len(leftIdx))*leftVar + float64(len(rightIdx))*rightVar) / float64(len(leftIdx)+len(rightIdx))
-	return totVar - weightedVar
+func (dt *DecTree) criterion() Criterion {
+	if dt.Criterion == nil {
+		return MSE{}
+	}
+	return dt.Criterion
 }
 
-func (dt *DecTree) bestSplit(indices []int) (bestFeature int, bestThreshold float64, bestScore float64) {
-	bestFeature = -1
-	bestScore = math.Inf(-1)
-
-	nFeatures := len(dt.X[0])
-	m := nFeatures
-	if dt.MaxFeatures != nil && *dt.MaxFeatures <= nFeatures && *dt.MaxFeatures > 0 {
-		m = *dt.MaxFeatures
+func (dt *DecTree) nodeImpurity(indices []int) float64 {
+	if dt.impurity != nil {
+		return dt.impurity(indices)
 	}
+	return dt.criterion().Impurity(dt.Y, indices)
+}
 
-	allFeatures := make([]int, nFeatures)
-	for i := 0; i < nFeatures; i++ {
-		allFeatures[i] = i
+// impurityDecrease is the impurity of the parent node minus the size weighted impurity of its children,
+// which is the variance reduction for the default MSE criterion. Criteria implementing SplitScorer replace it.
+func (dt *DecTree) impurityDecrease(leftIdx, rightIdx []int) float64 {
+	if scorer, ok := dt.criterion().(SplitScorer); ok && dt.impurity == nil {
+		return scorer.SplitScore(dt.Y, leftIdx, rightIdx)
 	}
-	dt.rng.Shuffle(nFeatures, func(i int, j int) {
-		allFeatures[i], allFeatures[j] = allFeatures[j], allFeatures[i]
-	})
-	features := allFeatures[:m]
-
-	for _, f := range features {
-		values := make([]float64, len(indices))
-		for i, idx := range indices {
-			values[i] = dt.X[idx][f]
-		}
-
-		uniques := sortedUnique(values)
-		if len(uniques) == 1 {
-			continue
-		}
-
-		for i := 0; i < len(uniques)-1; i++ {
-			threshold := (uniques[i] + uniques[i+1]) / 2
-			leftIdx, rightIdx := dt.splitIndices(indices, f, threshold)
-			if len(leftIdx) == 0 || len(rightIdx) == 0 {
-				continue
-			}
+	totImpurity := dt.nodeImpurity(slices.Concat(leftIdx, rightIdx))
+	leftImpurity := dt.nodeImpurity(leftIdx)
+	rightImpurity := dt.nodeImpurity(rightIdx)
 
-			score := dt.varianceReduction(dt.Y, leftIdx, rightIdx)
-			if score > bestScore {
-				bestScore = score
-				bestFeature = f
-				bestThreshold = threshold
-			}
-		}
-	}
-	return
+	weightedImpurity := (float64(len(leftIdx))*leftImpurity + float64(len(rightIdx))*rightImpurity) / float64(len(leftIdx)+len(rightIdx))
+	return totImpurity - weightedImpurity
 }
 
-func (dt *DecTree) buildTree(indices []int, depth int) *Node {
+// buildTree grows the subtree of the rows in sorted, which holds them ordered by each feature.
+func (dt *DecTree) buildTree(sorted [][]int, depth int) *Node {
+	indices := sorted[0]
+	leaf := func() *Node {
+		node := dt.createLeaf(indices)
+		node.nSamples, node.impurity = len(indices), dt.nodeImpurity(indices)
+		node.leafIndex = dt.nLeaves
+		dt.nLeaves++
+		return node
+	}
 	if depth >= dt.MaxDepth || len(indices) < dt.MinSamplesSplit {
-		return dt.createLeaf(indices)
+		return leaf()
 	}
 
-	featureIdx, threshold, _ := dt.bestSplit(indices)
+	featureIdx, threshold, _ := dt.bestSplit(sorted)
 	if featureIdx == -1 {
-		return dt.createLeaf(indices)
+		return leaf()
 	}
 
-	leftIdx, rightIdx := dt.splitIndices(indices, featureIdx, threshold)
-	if len(leftIdx) < dt.MinSamplesLeaf || len(rightIdx) < dt.MinSamplesLeaf {
-		return dt.createLeaf(indices)
+	leftIdx, rightIdx := dt.partition(sorted, featureIdx, threshold)
+	if len(leftIdx[0]) < dt.MinSamplesLeaf || len(rightIdx[0]) < dt.MinSamplesLeaf {
+		return leaf()
 	}
 
 	node := &Node{
 		featureIndex: featureIdx,
 		threshold:    threshold,
+		nSamples:     len(indices),
+		impurity:     dt.nodeImpurity(indices),
 	}
 	node.left = dt.buildTree(leftIdx, depth+1)
 	node.right = dt.buildTree(rightIdx, depth+1)
@@ -167,19 +183,6 @@ func (dt *DecTree) buildTree(indices []int, depth int) *Node {
 	return node
 }
 
-func sortedUnique(input []float64) []float64 {
-	isUnique := make(map[float64]bool)
-	for _, v := range input {
-		isUnique[v] = true
-	}
-	unique := make([]float64, 0, len(isUnique))
-	for k := range isUnique {
-		unique = append(unique, k)
-	}
-	slices.Sort(unique)
-	return unique
-}
-
 func varianceSubset(y []float64, indices []int) float64 {
 	if len(indices) == 0 {
 		return 0.0
@@ -197,12 +200,26 @@ func varianceSubset(y []float64, indices []int) float64 {
 	return variance / float64(len(indices))
 }
 
-func (dt *DecTree) Fit() {
+// grow builds the tree on every row of X.
+func (dt *DecTree) grow() {
+	if _, ok := dt.criterion().(Poisson); ok {
+		for _, target := range dt.Y {
+			if target < 0 {
+				panic("Poisson criterion requires non-negative targets")
+			}
+		}
+	}
+
 	indices := make([]int, len(dt.Y))
 	for i := range dt.Y {
 		indices[i] = i
 	}
-	dt.root = dt.buildTree(indices, 0)
+	dt.nLeaves = 0
+	dt.root = dt.buildTree(dt.presort(indices), 0)
+}
+
+func (dt *DecTree) Fit() {
+	dt.grow()
 
 	preds := make([]float64, len(dt.Y))
 	for i, row := range dt.X {
@@ -211,7 +228,7 @@ func (dt *DecTree) Fit() {
 	dt.Metrics = metrics.Evaluate(dt.Y, preds)
 }
 
-func (dt *DecTree) Predict(x []float64) float64 {
+func (dt *DecTree) leaf(x []float64) *Node {
 	node := dt.root
 	for !node.isLeaf {
 		if x[node.featureIndex] <= node.threshold {
@@ -220,7 +237,45 @@ func (dt *DecTree) Predict(x []float64) float64 {
 			node = node.right
 		}
 	}
-	return node.value
+	return node
+}
+
+// LeafIndex returns the index of the leaf x falls in, numbered in the order the leaves were grown.
+func (dt *DecTree) LeafIndex(x []float64) int {
+	return dt.leaf(x).leafIndex
+}
+
+func (dt *DecTree) NLeaves() int {
+	return dt.nLeaves
+}
+
+func (dt *DecTree) Predict(x []float64) float64 {
+	if dt.QuantileLeaves {
+		return dt.PredictQuantile(x, dt.Quantile)
+	}
+	return dt.leaf(x).value
+}
+
+// PredictQuantile returns the q-th quantile of the training targets in the leaf x falls into.
+// It requires the tree to be fitted in quantile-leaf mode.
+func (dt *DecTree) PredictQuantile(x []float64, q float64) float64 {
+	if !dt.QuantileLeaves {
+		panic("PredictQuantile requires a tree fitted with QuantileLeaves")
+	}
+	return metrics.Quantile(dt.leaf(x).samples, q)
+}
+
+// PredictQuantiles returns PredictQuantile(x, q) for each of the given quantiles with a single tree traversal.
+func (dt *DecTree) PredictQuantiles(x []float64, qs []float64) []float64 {
+	if !dt.QuantileLeaves {
+		panic("PredictQuantiles requires a tree fitted with QuantileLeaves")
+	}
+	samples := dt.leaf(x).samples
+	out := make([]float64, len(qs))
+	for i, q := range qs {
+		out[i] = metrics.Quantile(samples, q)
+	}
+	return out
 }
 
 func (dt *DecTree) GetMetrics() metrics.Metrics {
@@ -240,6 +295,35 @@ func (dt *DecTree) GetTreeString() string {
 	return buildString(dt.root, 0)
 }
 
+// ImpurityImportances returns the impurity-based (mean decrease in impurity) importance of every feature: the
+// decrease n * impurity - nLeft * impurityLeft - nRight * impurityRight summed over the splits on the feature, and
+// normalized to sum to 1. Unlike GetFeatureImportance it weights every split by how much it improved the fit.
+func (dt *DecTree) ImpurityImportances() []float64 {
+	importances := make([]float64, len(dt.X[0]))
+	var traverse func(node *Node)
+	traverse = func(node *Node) {
+		if node == nil || node.isLeaf {
+			return
+		}
+		importances[node.featureIndex] += float64(node.nSamples)*node.impurity -
+			float64(node.left.nSamples)*node.left.impurity - float64(node.right.nSamples)*node.right.impurity
+		traverse(node.left)
+		traverse(node.right)
+	}
+	traverse(dt.root)
+
+	total := 0.0
+	for _, v := range importances {
+		total += v
+	}
+	if total > 0 {
+		for f := range importances {
+			importances[f] /= total
+		}
+	}
+	return importances
+}
+
 func (dt *DecTree) GetFeatureImportance() map[int]float64 {
 	importance := make(map[int]float64)
 	var traverse func(node *Node)
@@ -0,0 +1,271 @@
+package DecTree
+
+import (
+	"GoML/metrics"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// syntheticData draws uniform features and a non-negative nonlinear target with half-normal noise.
+func syntheticData(nRows, nFeatures int) ([][]float64, []float64) {
+	rng := rand.New(rand.NewSource(1))
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = make([]float64, nFeatures)
+		for j := range x[i] {
+			x[i][j] = rng.Float64()
+		}
+		y[i] = 10*math.Sin(math.Pi*x[i][0]*x[i][1]) + 5*x[i][2] + math.Abs(rng.NormFloat64())
+	}
+	return x, y
+}
+
+// exhaustive hides the sweep of a criterion, so every threshold is scored from scratch as the split search used to.
+// Criteria without their own SplitScore are scored by their impurity decrease.
+type exhaustive struct {
+	Criterion
+}
+
+func (e exhaustive) Name() string { return "exhaustive_" + e.Criterion.Name() }
+
+func (e exhaustive) SplitScore(y []float64, leftIdx, rightIdx []int) float64 {
+	if scorer, ok := e.Criterion.(SplitScorer); ok {
+		return scorer.SplitScore(y, leftIdx, rightIdx)
+	}
+	nLeft, nRight := float64(len(leftIdx)), float64(len(rightIdx))
+	parent := e.Impurity(y, append(slices.Clone(leftIdx), rightIdx...))
+	return parent - (nLeft*e.Impurity(y, leftIdx)+nRight*e.Impurity(y, rightIdx))/(nLeft+nRight)
+}
+
+// sweepTestData rounds the synthetic targets so that the median heaps see ties, and zeroes the targets of the rows
+// with the smallest first feature so that Poisson children with a zero mean occur.
+func sweepTestData() ([][]float64, []float64) {
+	x, y := syntheticData(300, 3)
+	for i := range y {
+		y[i] = math.Round(y[i])
+		if x[i][0] < 0.1 {
+			y[i] = 0
+		}
+	}
+	return x, y
+}
+
+func allRows(n int) []int {
+	rows := make([]int, n)
+	for i := range rows {
+		rows[i] = i
+	}
+	return rows
+}
+
+func assertScoresMatch(t *testing.T, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d scores, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.IsInf(want[i], 0) || math.IsInf(got[i], 0) {
+			if got[i] != want[i] {
+				t.Fatalf("score %d = %v, want %v", i, got[i], want[i])
+			}
+			continue
+		}
+		if math.Abs(got[i]-want[i]) > 1e-9*math.Max(1, math.Abs(want[i])) {
+			t.Fatalf("score %d = %.12g, want %.12g", i, got[i], want[i])
+		}
+	}
+}
+
+// treesEqual compares the splits, leaf values and node statistics of two trees.
+func treesEqual(a, b *Node) bool {
+	if a.isLeaf != b.isLeaf || a.nSamples != b.nSamples || a.value != b.value || !slices.Equal(a.distribution, b.distribution) {
+		return false
+	}
+	if a.isLeaf {
+		return true
+	}
+	return a.featureIndex == b.featureIndex && a.threshold == b.threshold && treesEqual(a.left, b.left) && treesEqual(a.right, b.right)
+}
+
+func TestSweepMatchesImpurityDecrease(t *testing.T) {
+	x, y := sweepTestData()
+	for _, criterion := range []Criterion{MSE{}, FriedmanMSE{}, MAE{}, Poisson{}} {
+		t.Run(criterion.Name(), func(t *testing.T) {
+			seed := int64(1)
+			dt := NewDecTree(x, y, 6, 2, 1, &seed, nil).(*DecTree)
+			dt.Criterion = criterion
+			for _, rows := range dt.presort(allRows(len(y))) {
+				want := make([]float64, len(rows)-1)
+				for i := range want {
+					want[i] = dt.impurityDecrease(rows[:i+1], rows[i+1:])
+				}
+				got := dt.sweepScores(rows)
+				if got == nil {
+					t.Fatalf("%s does not sweep", criterion.Name())
+				}
+				assertScoresMatch(t, got, want)
+			}
+		})
+	}
+
+	t.Run("poisson zero child", func(t *testing.T) {
+		// Rows sorted by the first feature start with zero targets, so the first thresholds leave a zero-mean child
+		seed := int64(1)
+		dt := NewDecTree(x, y, 6, 2, 1, &seed, nil).(*DecTree)
+		dt.Criterion = Poisson{}
+		rows := dt.presort(allRows(len(y)))[0]
+		if scores := dt.sweepScores(rows); !math.IsInf(scores[0], -1) {
+			t.Errorf("score of a zero-mean left child = %v, want -Inf", scores[0])
+		}
+	})
+
+	for _, criterion := range []string{"gini", "entropy"} {
+		t.Run(criterion, func(t *testing.T) {
+			labels := make([]float64, len(y))
+			for i := range y {
+				labels[i] = math.Min(math.Floor(y[i]/5), 2)
+			}
+			seed := int64(1)
+			dtc := NewDecTreeClassifier(x, labels, 6, 2, 1, criterion, &seed, nil).(*DecTreeClassifier)
+			for _, rows := range dtc.presort(allRows(len(y))) {
+				want := make([]float64, len(rows)-1)
+				for i := range want {
+					want[i] = dtc.impurityDecrease(rows[:i+1], rows[i+1:])
+				}
+				assertScoresMatch(t, dtc.sweepScores(rows), want)
+			}
+		})
+	}
+}
+
+// Trees are compared on continuous targets, keeping nodes large enough that no two candidate splits produce the same
+// children: those tie exactly, and rounding differences between the two scorings would decide between them.
+func TestSweepGrowsSameTree(t *testing.T) {
+	x, y := syntheticData(300, 3)
+	for i := range y {
+		if x[i][0] < 0.1 {
+			y[i] = 0
+		}
+	}
+	for _, criterion := range []Criterion{MSE{}, FriedmanMSE{}, MAE{}, Poisson{}} {
+		t.Run(criterion.Name(), func(t *testing.T) {
+			seed := int64(1)
+			swept := NewDecTree(x, y, 6, 20, 5, &seed, nil).(*DecTree)
+			swept.Criterion = criterion
+			swept.grow()
+			scratch := NewDecTree(x, y, 6, 20, 5, &seed, nil).(*DecTree)
+			scratch.Criterion = exhaustive{criterion}
+			scratch.grow()
+			if !treesEqual(swept.root, scratch.root) {
+				t.Errorf("sweeping grew a different tree than scoring every threshold from scratch")
+			}
+		})
+	}
+
+	t.Run("gini", func(t *testing.T) {
+		labels := make([]float64, len(y))
+		for i := range y {
+			labels[i] = math.Min(math.Floor(y[i]/5), 2)
+		}
+		seed := int64(1)
+		swept := NewDecTreeClassifier(x, labels, 6, 20, 5, "gini", &seed, nil).(*DecTreeClassifier)
+		swept.grow()
+		scratch := NewDecTreeClassifier(x, labels, 6, 20, 5, "gini", &seed, nil).(*DecTreeClassifier)
+		scratch.sweep = nil
+		scratch.grow()
+		if !treesEqual(swept.root, scratch.root) {
+			t.Errorf("sweeping grew a different tree than scoring every threshold from scratch")
+		}
+	})
+}
+
+func TestQuantileLeafMatchesQuantile(t *testing.T) {
+	x, y := syntheticData(101, 3)
+	seed := int64(1)
+	// A tree of depth zero is a single leaf holding every target
+	dt := NewQuantileDecTree(x, y, 0, 2, 1, 0.5, &seed, nil).(*DecTree)
+	dt.Fit()
+	for _, q := range []float64{0, 0.1, 0.5, 0.75, 1} {
+		if got, want := dt.PredictQuantile(x[0], q), metrics.QuantileUnsorted(y, q); got != want {
+			t.Errorf("PredictQuantile(%v) = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestQuantileLeavesCoverage(t *testing.T) {
+	x, y := syntheticData(4000, 3)
+	xTrain, yTrain, xTest, yTest := x[:2000], y[:2000], x[2000:], y[2000:]
+	qs := []float64{0.1, 0.5, 0.9}
+	for _, q := range qs {
+		seed := int64(1)
+		dt := NewQuantileDecTree(xTrain, yTrain, 6, 100, 50, q, &seed, nil).(*DecTree)
+		dt.Fit()
+
+		below := 0
+		for i, row := range xTest {
+			pred := dt.Predict(row)
+			if pred != dt.PredictQuantile(row, q) {
+				t.Fatalf("q=%v: Predict %v differs from PredictQuantile", q, pred)
+			}
+			for k, pred := range dt.PredictQuantiles(row, qs) {
+				if pred != dt.PredictQuantile(row, qs[k]) {
+					t.Fatalf("q=%v: PredictQuantiles differs from PredictQuantile at %v", q, qs[k])
+				}
+			}
+			if yTest[i] <= pred {
+				below++
+			}
+		}
+		if share := float64(below) / float64(len(yTest)); math.Abs(share-q) > 0.05 {
+			t.Errorf("q=%v: %v of the held-out targets lie below the prediction", q, share)
+		}
+	}
+}
+
+func benchmarkFit(b *testing.B, nRows int, criterion Criterion) {
+	x, y := syntheticData(nRows, 5)
+	seed := int64(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dt := NewDecTree(x, y, 6, 2, 1, &seed, nil).(*DecTree)
+		dt.Criterion = criterion
+		dt.grow()
+	}
+}
+
+func BenchmarkFit(b *testing.B) {
+	for _, nRows := range []int{1000, 10000, 100000} {
+		for _, criterion := range []Criterion{MSE{}, FriedmanMSE{}, MAE{}, Poisson{}} {
+			b.Run(fmt.Sprintf("%s/rows=%d", criterion.Name(), nRows), func(b *testing.B) {
+				benchmarkFit(b, nRows, criterion)
+			})
+		}
+	}
+}
+
+func BenchmarkFitClassifier(b *testing.B) {
+	x, y := syntheticData(100000, 5)
+	for i := range y {
+		y[i] = math.Floor(math.Max(0, math.Min(y[i]/5, 2)))
+	}
+	seed := int64(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewDecTreeClassifier(x, y, 6, 2, 1, "gini", &seed, nil).(*DecTreeClassifier).grow()
+	}
+}
+
+// BenchmarkFitExhaustive is the baseline for BenchmarkFit. Scoring every threshold from scratch is quadratic in the
+// node size: a tenfold increase in rows costs a hundredfold in time, so 100k rows (around 15 minutes per tree) are
+// left out.
+func BenchmarkFitExhaustive(b *testing.B) {
+	for _, nRows := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("rows=%d", nRows), func(b *testing.B) {
+			benchmarkFit(b, nRows, exhaustive{MSE{}})
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package DecTree
+
+import (
+	"cmp"
+	"container/heap"
+	"math"
+	"slices"
+)
+
+// Split search: every feature is sorted once when the tree is grown, and each node carries its rows in the order of
+// every feature. Scoring all thresholds of a feature is then a single sweep that moves rows from the right child to
+// the left one while updating running sums, and children inherit sorted orders by a stable partition.
+
+// sweeper is implemented by criteria that score every threshold of a feature in one pass over the rows of a node
+// sorted by that feature. scores[i] is the split score of sending rows[:i+1] left and rows[i+1:] right.
+// Criteria without it are scored threshold by threshold, which is quadratic in the node size.
+type sweeper interface {
+	sweepScores(y []float64, rows []int) []float64
+}
+
+// presort returns the given rows ordered by each feature.
+func (dt *DecTree) presort(indices []int) [][]int {
+	sorted := make([][]int, len(dt.X[0]))
+	for f := range sorted {
+		rows := slices.Clone(indices)
+		slices.SortStableFunc(rows, func(a, b int) int {
+			return cmp.Compare(dt.X[a][f], dt.X[b][f])
+		})
+		sorted[f] = rows
+	}
+	return sorted
+}
+
+// partition splits the feature orderings of a node into those of its children, which stay sorted.
+func (dt *DecTree) partition(sorted [][]int, featureIdx int, threshold float64) (left, right [][]int) {
+	nLeft := 0
+	for _, idx := range sorted[featureIdx] {
+		if dt.X[idx][featureIdx] <= threshold {
+			nLeft++
+		}
+	}
+
+	left = make([][]int, len(sorted))
+	right = make([][]int, len(sorted))
+	for f, rows := range sorted {
+		left[f] = make([]int, 0, nLeft)
+		right[f] = make([]int, 0, len(rows)-nLeft)
+		for _, idx := range rows {
+			if dt.X[idx][featureIdx] <= threshold {
+				left[f] = append(left[f], idx)
+			} else {
+				right[f] = append(right[f], idx)
+			}
+		}
+	}
+	return left, right
+}
+
+// sweepScores scores every threshold of rows sorted by a feature, or returns nil when neither the tree variant nor
+// its criterion support sweeping.
+func (dt *DecTree) sweepScores(rows []int) []float64 {
+	if dt.sweep != nil {
+		return dt.sweep(rows)
+	}
+	if dt.impurity != nil {
+		return nil
+	}
+	if s, ok := dt.criterion().(sweeper); ok {
+		return s.sweepScores(dt.Y, rows)
+	}
+	return nil
+}
+
+func (dt *DecTree) bestSplit(sorted [][]int) (bestFeature int, bestThreshold float64, bestScore float64) {
+	bestFeature = -1
+	bestScore = math.Inf(-1)
+
+	nFeatures := len(dt.X[0])
+	m := nFeatures
+	if dt.MaxFeatures != nil && *dt.MaxFeatures <= nFeatures && *dt.MaxFeatures > 0 {
+		m = *dt.MaxFeatures
+	}
+
+	allFeatures := make([]int, nFeatures)
+	for i := 0; i < nFeatures; i++ {
+		allFeatures[i] = i
+	}
+	dt.rng.Shuffle(nFeatures, func(i int, j int) {
+		allFeatures[i], allFeatures[j] = allFeatures[j], allFeatures[i]
+	})
+	features := allFeatures[:m]
+
+	for _, f := range features {
+		rows := sorted[f]
+		if dt.X[rows[0]][f] == dt.X[rows[len(rows)-1]][f] {
+			continue
+		}
+		if dt.RandomSplits {
+			if threshold, score := dt.randomSplit(rows, f); score > bestScore {
+				bestScore, bestFeature, bestThreshold = score, f, threshold
+			}
+			continue
+		}
+
+		scores := dt.sweepScores(rows)
+		for i := 0; i < len(rows)-1; i++ {
+			lower, upper := dt.X[rows[i]][f], dt.X[rows[i+1]][f]
+			if lower == upper {
+				continue
+			}
+
+			var score float64
+			if scores != nil {
+				score = scores[i]
+			} else {
+				score = dt.impurityDecrease(rows[:i+1], rows[i+1:])
+			}
+			if score > bestScore {
+				bestScore = score
+				bestFeature = f
+				bestThreshold = (lower + upper) / 2
+			}
+		}
+	}
+	return
+}
+
+// randomSplit draws a threshold uniformly between the smallest and largest value of feature f over rows, which are
+// sorted by it, and scores the split.
+func (dt *DecTree) randomSplit(rows []int, f int) (threshold, score float64) {
+	lowest, highest := dt.X[rows[0]][f], dt.X[rows[len(rows)-1]][f]
+	threshold = lowest + dt.rng.Float64()*(highest-lowest)
+	if threshold >= highest { // Rounding on tiny ranges
+		threshold = lowest
+	}
+	// rows[:nLeft] go left, and there is at least one row on each side as lowest <= threshold < highest
+	nLeft, _ := slices.BinarySearchFunc(rows, threshold, func(row int, t float64) int {
+		if dt.X[row][f] <= t {
+			return -1
+		}
+		return 1
+	})
+
+	if scores := dt.sweepScores(rows); scores != nil {
+		return threshold, scores[nLeft-1]
+	}
+	return threshold, dt.impurityDecrease(rows[:nLeft], rows[nLeft:])
+}
+
+func (MSE) sweepScores(y []float64, rows []int) []float64 {
+	return squaredErrorSweep(y, rows, false)
+}
+
+func (FriedmanMSE) sweepScores(y []float64, rows []int) []float64 {
+	return squaredErrorSweep(y, rows, true)
+}
+
+// squaredErrorSweep keeps the running sum of the targets on the left. With the targets centered on the node mean,
+// the variance reduction is (sumLeft^2 / nLeft + sumRight^2 / nRight) / n.
+func squaredErrorSweep(y []float64, rows []int, friedman bool) []float64 {
+	n := float64(len(rows))
+	mean := meanSubset(y, rows)
+	total := 0.0 // Zero up to rounding
+	for _, idx := range rows {
+		total += y[idx] - mean
+	}
+
+	scores := make([]float64, len(rows)-1)
+	leftSum := 0.0
+	for i := range scores {
+		leftSum += y[rows[i]] - mean
+		rightSum := total - leftSum
+		nLeft := float64(i + 1)
+		nRight := n - nLeft
+
+		if friedman {
+			diff := leftSum/nLeft - rightSum/nRight
+			scores[i] = nLeft * nRight / n * diff * diff
+		} else {
+			scores[i] = (leftSum*leftSum/nLeft + rightSum*rightSum/nRight - total*total/n) / n
+		}
+	}
+	return scores
+}
+
+// poissonDeviance is the half Poisson deviance of count rows around their mean, given the sums of y log(y) and y.
+func poissonDeviance(sumYLogY, sum, count float64) float64 {
+	return sumYLogY - sum*math.Log(sum/count)
+}
+
+func yLogY(y float64) float64 {
+	if y <= 0 {
+		return 0.0
+	}
+	return y * math.Log(y)
+}
+
+// sweepScores sums the right child from the end so that an all-zero child is detected exactly.
+func (Poisson) sweepScores(y []float64, rows []int) []float64 {
+	n := len(rows)
+	suffixSum := make([]float64, n+1)
+	suffixYLogY := make([]float64, n+1)
+	for i := n - 1; i >= 0; i-- {
+		suffixSum[i] = suffixSum[i+1] + y[rows[i]]
+		suffixYLogY[i] = suffixYLogY[i+1] + yLogY(y[rows[i]])
+	}
+	parent := poissonDeviance(suffixYLogY[0], suffixSum[0], float64(n))
+
+	scores := make([]float64, n-1)
+	leftSum, leftYLogY := 0.0, 0.0
+	for i := range scores {
+		leftSum += y[rows[i]]
+		leftYLogY += yLogY(y[rows[i]])
+		if leftSum <= 0 || suffixSum[i+1] <= 0 {
+			scores[i] = math.Inf(-1)
+			continue
+		}
+		children := poissonDeviance(leftYLogY, leftSum, float64(i+1)) + poissonDeviance(suffixYLogY[i+1], suffixSum[i+1], float64(n-i-1))
+		scores[i] = (parent - children) / float64(n)
+	}
+	return scores
+}
+
+// sweepScores adds the rows one at a time to a running median from the left and then from the right, so that the
+// absolute deviation of every prefix and suffix is known in O(n log n).
+func (MAE) sweepScores(y []float64, rows []int) []float64 {
+	n := len(rows)
+	prefix := make([]float64, n)
+	suffix := make([]float64, n)
+
+	var acc medianAccumulator
+	for i, idx := range rows {
+		acc.push(y[idx])
+		prefix[i] = acc.deviation()
+	}
+	acc = medianAccumulator{}
+	for i := n - 1; i >= 0; i-- {
+		acc.push(y[rows[i]])
+		suffix[i] = acc.deviation()
+	}
+
+	scores := make([]float64, n-1)
+	for i := range scores {
+		scores[i] = (prefix[n-1] - prefix[i] - suffix[i+1]) / float64(n)
+	}
+	return scores
+}
+
+// floatHeap is a min-heap of float64 for container/heap.
+type floatHeap []float64
+
+func (h floatHeap) Len() int           { return len(h) }
+func (h floatHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h floatHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *floatHeap) Push(x any)        { *h = append(*h, x.(float64)) }
+func (h *floatHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// medianAccumulator tracks the sum of absolute deviations from the median of a growing set of values. The lower
+// half is a max-heap (stored negated) holding the median, the upper half a min-heap.
+type medianAccumulator struct {
+	lower, upper       floatHeap
+	lowerSum, upperSum float64
+}
+
+func (acc *medianAccumulator) push(v float64) {
+	if acc.lower.Len() == 0 || v <= -acc.lower[0] {
+		heap.Push(&acc.lower, -v)
+		acc.lowerSum += v
+	} else {
+		heap.Push(&acc.upper, v)
+		acc.upperSum += v
+	}
+
+	if acc.lower.Len() > acc.upper.Len()+1 {
+		moved := -heap.Pop(&acc.lower).(float64)
+		acc.lowerSum -= moved
+		heap.Push(&acc.upper, moved)
+		acc.upperSum += moved
+	} else if acc.upper.Len() > acc.lower.Len() {
+		moved := heap.Pop(&acc.upper).(float64)
+		acc.upperSum -= moved
+		heap.Push(&acc.lower, -moved)
+		acc.lowerSum += moved
+	}
+}
+
+// deviation is the sum of |v - median|, which does not depend on which median is taken for even counts.
+func (acc *medianAccumulator) deviation() float64 {
+	median := -acc.lower[0]
+	return acc.upperSum - acc.lowerSum + median*float64(acc.lower.Len()-acc.upper.Len())
+}
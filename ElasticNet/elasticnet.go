@@ -0,0 +1,244 @@
+package ElasticNet
+
+import (
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"fmt"
+	"math"
+)
+
+// ElasticNet minimizes 1/(2n) * ||y - Xb||^2 + Alpha * L1Ratio * ||b||_1 + Alpha * (1 - L1Ratio) / 2 * ||b||^2
+// through cyclic coordinate descent. Lasso is the special case of L1Ratio == 1.
+type ElasticNet struct {
+	X            [][]float64 `json:"X,omitempty"`     // nd-array[float64]
+	Y            []float64   `json:"y,omitempty"`     // 1d-array[float64]
+	Coefs        []float64   `json:"coefs,omitempty"` // 1d-array[float64]
+	Intercept    float64     `json:"intercept,omitempty"`
+	Alpha        float64     `json:"alpha"`
+	L1Ratio      float64     `json:"l1_ratio"`
+	MaxIter      int         `json:"max_iter"`
+	Tol          float64     `json:"tol"`
+	FitIntercept bool        `json:"fit_intercept"`
+
+	// Fit results
+	ZeroCoefs []int `json:"zero_coefs"` // Indices of coefficients driven to exactly zero
+	NIter     int   `json:"n_iter"`
+	Converged bool  `json:"converged"`
+
+	Metrics metrics.Metrics
+}
+
+func NewElasticNet(X [][]float64, Y []float64, alpha, l1Ratio float64, maxIter int, tol float64, fitIntercept bool) Ensemble.Estimator {
+	if len(X) == 0 || len(Y) == 0 {
+		panic("X and Y cannot be empty")
+	}
+	if len(X) != len(Y) {
+		panic("X and Y must have the same number of rows")
+	}
+	for i := range X {
+		if len(X[i]) == 0 {
+			panic("X cannot have empty rows")
+		}
+	}
+	if alpha < 0 || math.IsNaN(alpha) {
+		panic("Alpha must be a non-negative number")
+	}
+	if l1Ratio < 0 || l1Ratio > 1 {
+		panic("L1Ratio must be in [0, 1]")
+	}
+	if maxIter <= 0 {
+		panic("MaxIter must be positive")
+	}
+
+	preAllocX := make([][]float64, len(X))
+	for i := range X {
+		preAllocX[i] = make([]float64, len(X[i]))
+		copy(preAllocX[i], X[i])
+	}
+
+	preAllocY := make([]float64, len(Y))
+	for i, val := range Y {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			panic(fmt.Sprintf("Y contains NaN or Inf at index %d", i))
+		}
+		if val == 0.0 {
+			val = 1e-8 // Avoid Div0
+		}
+		preAllocY[i] = val
+	}
+
+	return &ElasticNet{
+		X:            preAllocX,
+		Y:            preAllocY,
+		Coefs:        make([]float64, len(X[0])),
+		Alpha:        alpha,
+		L1Ratio:      l1Ratio,
+		MaxIter:      maxIter,
+		Tol:          tol,
+		FitIntercept: fitIntercept,
+	}
+}
+
+func NewLasso(X [][]float64, Y []float64, alpha float64, maxIter int, tol float64, fitIntercept bool) Ensemble.Estimator {
+	return NewElasticNet(X, Y, alpha, 1.0, maxIter, tol, fitIntercept)
+}
+
+func NewDefaultElasticNet(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewElasticNet(X, Y, 1.0, 0.5, 1000, 1e-4, true)
+}
+
+func NewDefaultLasso(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewLasso(X, Y, 1.0, 1000, 1e-4, true)
+}
+
+func softThreshold(z, gamma float64) float64 {
+	switch {
+	case z > gamma:
+		return z - gamma
+	case z < -gamma:
+		return z + gamma
+	default:
+		return 0.0
+	}
+}
+
+// centeredColumns returns the feature matrix in column-major order (centered if fitIntercept is set)
+// alongside the column means, the target mean and the centered target.
+func centeredColumns(x [][]float64, y []float64, fitIntercept bool) (cols [][]float64, xMeans []float64, yMean float64, yCentered []float64) {
+	nRows, nCols := len(x), len(x[0])
+	xMeans = make([]float64, nCols)
+	if fitIntercept {
+		for _, row := range x {
+			for j, val := range row {
+				xMeans[j] += val
+			}
+		}
+		for j := range xMeans {
+			xMeans[j] /= float64(nRows)
+		}
+		for _, val := range y {
+			yMean += val
+		}
+		yMean /= float64(nRows)
+	}
+
+	cols = make([][]float64, nCols)
+	for j := range cols {
+		cols[j] = make([]float64, nRows)
+		for i, row := range x {
+			cols[j][i] = row[j] - xMeans[j]
+		}
+	}
+
+	yCentered = make([]float64, nRows)
+	for i, val := range y {
+		yCentered[i] = val - yMean
+	}
+	return
+}
+
+// coordinateDescent updates coefs in place and returns the number of sweeps and whether the
+// largest coefficient update of the final sweep fell below tol.
+func coordinateDescent(cols [][]float64, y, coefs []float64, alpha, l1Ratio float64, maxIter int, tol float64) (int, bool) {
+	nRows := float64(len(y))
+	l1 := alpha * l1Ratio * nRows
+	l2 := alpha * (1 - l1Ratio) * nRows
+
+	colNorms := make([]float64, len(cols))
+	for j, col := range cols {
+		for _, val := range col {
+			colNorms[j] += val * val
+		}
+	}
+
+	resid := make([]float64, len(y))
+	copy(resid, y)
+	for j, col := range cols {
+		if coefs[j] == 0 {
+			continue
+		}
+		for i, val := range col {
+			resid[i] -= val * coefs[j]
+		}
+	}
+
+	for iter := 1; iter <= maxIter; iter++ {
+		maxDelta := 0.0
+		maxCoef := 0.0
+		for j, col := range cols {
+			if colNorms[j] == 0 {
+				coefs[j] = 0
+				continue
+			}
+			old := coefs[j]
+
+			rho := 0.0
+			for i, val := range col {
+				rho += val * (resid[i] + val*old)
+			}
+			updated := softThreshold(rho, l1) / (colNorms[j] + l2)
+
+			if delta := updated - old; delta != 0 {
+				for i, val := range col {
+					resid[i] -= val * delta
+				}
+				maxDelta = math.Max(maxDelta, math.Abs(delta))
+			}
+			coefs[j] = updated
+			maxCoef = math.Max(maxCoef, math.Abs(updated))
+		}
+		if maxCoef == 0 || maxDelta/maxCoef < tol {
+			return iter, true
+		}
+	}
+	return maxIter, false
+}
+
+func (en *ElasticNet) Fit() {
+	cols, xMeans, yMean, yCentered := centeredColumns(en.X, en.Y, en.FitIntercept)
+
+	en.Coefs = make([]float64, len(cols))
+	en.NIter, en.Converged = coordinateDescent(cols, yCentered, en.Coefs, en.Alpha, en.L1Ratio, en.MaxIter, en.Tol)
+
+	en.Intercept = yMean
+	en.ZeroCoefs = make([]int, 0)
+	for j, coef := range en.Coefs {
+		en.Intercept -= coef * xMeans[j]
+		if coef == 0 {
+			en.ZeroCoefs = append(en.ZeroCoefs, j)
+		}
+	}
+
+	preds := make([]float64, len(en.Y))
+	for i, row := range en.X {
+		preds[i] = en.Predict(row)
+	}
+	en.Metrics = metrics.Evaluate(en.Y, preds)
+}
+
+func (en *ElasticNet) Predict(x []float64) float64 {
+	if len(x) != len(en.Coefs) {
+		panic("Input feature length does not match number of coefficients")
+	}
+
+	pred := en.Intercept
+	for i, coef := range en.Coefs {
+		pred += coef * x[i]
+	}
+	return pred
+}
+
+// GetSelectedFeatures returns the indices of features with a non-zero coefficient.
+func (en *ElasticNet) GetSelectedFeatures() []int {
+	selected := make([]int, 0, len(en.Coefs))
+	for j, coef := range en.Coefs {
+		if coef != 0 {
+			selected = append(selected, j)
+		}
+	}
+	return selected
+}
+
+func (en *ElasticNet) GetMetrics() metrics.Metrics {
+	return en.Metrics
+}
@@ -0,0 +1,114 @@
+package ElasticNet
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+	"testing"
+)
+
+// hadamard returns the given columns of the 8 x 8 Sylvester Hadamard matrix, whose entry (i, j) is -1 to the number
+// of bits i and j share. Columns other than 0 have zero mean and are orthogonal with squared norm 8.
+func hadamard(columns ...int) [][]float64 {
+	x := make([][]float64, 8)
+	for i := range x {
+		x[i] = make([]float64, len(columns))
+		for k, j := range columns {
+			x[i][k] = 1 - 2*float64(bits.OnesCount(uint(i&j))%2)
+		}
+	}
+	return x
+}
+
+// On an orthogonal design with X^T X = n I the lasso solution is the soft-thresholded correlation
+// sign(x_j^T y / n) * max(|x_j^T y / n| - alpha, 0).
+func TestLassoOrthogonalDesign(t *testing.T) {
+	x := hadamard(1, 2, 4)
+	// Column 7 is orthogonal to the features and only adds residual noise
+	noise := hadamard(7)
+	y := make([]float64, len(x))
+	for i, row := range x {
+		y[i] = 10 + 3*row[0] - 0.5*row[1] + 0.2*row[2] + noise[i][0]
+	}
+
+	for _, tc := range []struct {
+		alpha     float64
+		coefs     []float64
+		zeroCoefs []int
+	}{
+		{0.1, []float64{2.9, -0.4, 0.1}, []int{}},
+		{0.3, []float64{2.7, -0.2, 0}, []int{2}},
+		{1, []float64{2, 0, 0}, []int{1, 2}},
+		{5, []float64{0, 0, 0}, []int{0, 1, 2}},
+	} {
+		lasso := NewLasso(x, y, tc.alpha, 1000, 1e-10, true).(*ElasticNet)
+		lasso.Fit()
+		if !lasso.Converged {
+			t.Errorf("alpha %v: did not converge", tc.alpha)
+		}
+		if math.Abs(lasso.Intercept-10) > 1e-12 {
+			t.Errorf("alpha %v: intercept %v, want 10", tc.alpha, lasso.Intercept)
+		}
+		for j, want := range tc.coefs {
+			if math.Abs(lasso.Coefs[j]-want) > 1e-12 {
+				t.Errorf("alpha %v: coefficients %v, want %v", tc.alpha, lasso.Coefs, tc.coefs)
+				break
+			}
+		}
+		if !slices.Equal(lasso.ZeroCoefs, tc.zeroCoefs) {
+			t.Errorf("alpha %v: ZeroCoefs %v, want %v", tc.alpha, lasso.ZeroCoefs, tc.zeroCoefs)
+		}
+		selected := []int{}
+		for j := range tc.coefs {
+			if !slices.Contains(tc.zeroCoefs, j) {
+				selected = append(selected, j)
+			}
+		}
+		if got := lasso.GetSelectedFeatures(); !slices.Equal(got, selected) {
+			t.Errorf("alpha %v: selected features %v, want %v", tc.alpha, got, selected)
+		}
+	}
+}
+
+// On correlated features the solution is checked through its optimality conditions: the correlation of every feature
+// with the residuals, x_j^T r / n, equals alpha * l1Ratio * sign(b_j) + alpha * (1 - l1Ratio) * b_j for a non-zero
+// coefficient and is at most alpha * l1Ratio in absolute value for a zero one.
+func TestElasticNetOptimality(t *testing.T) {
+	x, y := sparseLinear(50, 8, 3)
+	for i := range x {
+		// Correlate the second feature with the first
+		x[i][1] += 0.8 * x[i][0]
+	}
+	cols, _, _, yCentered := centeredColumns(x, y, true)
+	n := float64(len(y))
+
+	for _, l1Ratio := range []float64{1, 0.5} {
+		for _, alpha := range []float64{0.05, 0.3, 1} {
+			en := NewElasticNet(x, y, alpha, l1Ratio, 100000, 1e-12, true).(*ElasticNet)
+			en.Fit()
+
+			resid := slices.Clone(yCentered)
+			for j, col := range cols {
+				for i, val := range col {
+					resid[i] -= val * en.Coefs[j]
+				}
+			}
+			for j, col := range cols {
+				corr := 0.0
+				for i, val := range col {
+					corr += val * resid[i] / n
+				}
+				coef := en.Coefs[j]
+				switch {
+				case coef == 0 && math.Abs(corr) > alpha*l1Ratio+1e-9:
+					t.Errorf("l1Ratio %v, alpha %v: zero coefficient %d has correlation %v above %v", l1Ratio, alpha, j, corr, alpha*l1Ratio)
+				case coef != 0 && math.Abs(corr-alpha*l1Ratio*math.Copysign(1, coef)-alpha*(1-l1Ratio)*coef) > 1e-9:
+					t.Errorf("l1Ratio %v, alpha %v: coefficient %d = %v has correlation %v", l1Ratio, alpha, j, coef, corr)
+				}
+			}
+			if alpha == 1 && len(en.ZeroCoefs) == 0 {
+				t.Errorf("l1Ratio %v: no coefficient zeroed at alpha 1", l1Ratio)
+			}
+		}
+	}
+}
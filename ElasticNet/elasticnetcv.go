@@ -0,0 +1,213 @@
+package ElasticNet
+
+import (
+	"GoML/Ensemble"
+	"GoML/crossval"
+	"GoML/metrics"
+	"math"
+	"math/rand"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+type ElasticNetCV struct {
+	X            [][]float64 `json:"X,omitempty"`
+	Y            []float64   `json:"y,omitempty"`
+	L1Ratio      float64     `json:"l1_ratio"`
+	NAlphas      int         `json:"n_alphas"`
+	Eps          float64     `json:"eps"` // alpha_min / alpha_max of the grid
+	NFolds       int         `json:"n_folds"`
+	MaxIter      int         `json:"max_iter"`
+	Tol          float64     `json:"tol"`
+	FitIntercept bool        `json:"fit_intercept"`
+
+	// Fit results
+	Alphas    []float64            `json:"alphas"`
+	BestAlpha float64              `json:"best_alpha"`
+	Coefs     []float64            `json:"coefs,omitempty"`
+	Intercept float64              `json:"intercept,omitempty"`
+	ZeroCoefs []int                `json:"zero_coefs"`
+	Path      []crossval.PathPoint `json:"path"`
+
+	Metrics metrics.Metrics
+
+	RandSeed *int64 `json:"random_seed"`
+	rng      *rand.Rand
+}
+
+func NewElasticNetCV(X [][]float64, Y []float64, l1Ratio float64, nAlphas, nFolds, maxIter int, tol float64, fitIntercept bool, randSeed *int64) Ensemble.Estimator {
+	if l1Ratio <= 0 || l1Ratio > 1 {
+		panic("L1Ratio must be in (0, 1] to derive the alpha grid")
+	}
+	if nAlphas <= 0 {
+		panic("nAlphas must be positive")
+	}
+	if nFolds < 2 || nFolds > len(Y) {
+		panic("nFolds must be in [2, nRows]")
+	}
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+
+	// Reuse ElasticNet's validation and copying of the data
+	base := NewElasticNet(X, Y, 0, l1Ratio, maxIter, tol, fitIntercept).(*ElasticNet)
+
+	return &ElasticNetCV{
+		X:            base.X,
+		Y:            base.Y,
+		L1Ratio:      l1Ratio,
+		NAlphas:      nAlphas,
+		Eps:          1e-3,
+		NFolds:       nFolds,
+		MaxIter:      maxIter,
+		Tol:          tol,
+		FitIntercept: fitIntercept,
+		Coefs:        base.Coefs,
+		RandSeed:     randSeed,
+		rng:          rand.New(rand.NewSource(*randSeed)),
+	}
+}
+
+func NewLassoCV(X [][]float64, Y []float64, nAlphas, nFolds, maxIter int, tol float64, fitIntercept bool, randSeed *int64) Ensemble.Estimator {
+	return NewElasticNetCV(X, Y, 1.0, nAlphas, nFolds, maxIter, tol, fitIntercept, randSeed)
+}
+
+func NewDefaultElasticNetCV(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewElasticNetCV(X, Y, 0.5, 100, 5, 1000, 1e-4, true, nil)
+}
+
+func NewDefaultLassoCV(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewLassoCV(X, Y, 100, 5, 1000, 1e-4, true, nil)
+}
+
+// alphaMax is the smallest alpha for which all coefficients are zero: max_j |x_j^T y| / (n * l1Ratio). It is nudged
+// up by a relative 1e-12, or the rounding of alpha * l1Ratio * n in coordinateDescent can leave that largest
+// correlation just above the threshold and its coefficient at a tiny non-zero value.
+func alphaMax(cols [][]float64, y []float64, l1Ratio float64) float64 {
+	maxCorr := 0.0
+	for _, col := range cols {
+		corr := 0.0
+		for i, val := range col {
+			corr += val * y[i]
+		}
+		maxCorr = math.Max(maxCorr, math.Abs(corr))
+	}
+	if maxCorr == 0 {
+		return 1.0
+	}
+	return maxCorr / (float64(len(y)) * l1Ratio) * (1 + 1e-12)
+}
+
+// fitPath fits the alphas in the given (descending) order, warm starting each fit from the previous solution.
+func (cv *ElasticNetCV) fitPath(x [][]float64, y []float64) (coefs [][]float64, intercepts []float64) {
+	cols, xMeans, yMean, yCentered := centeredColumns(x, y, cv.FitIntercept)
+
+	coefs = make([][]float64, len(cv.Alphas))
+	intercepts = make([]float64, len(cv.Alphas))
+	warm := make([]float64, len(cols))
+	for a, alpha := range cv.Alphas {
+		coordinateDescent(cols, yCentered, warm, alpha, cv.L1Ratio, cv.MaxIter, cv.Tol)
+
+		coefs[a] = make([]float64, len(warm))
+		copy(coefs[a], warm)
+		intercepts[a] = yMean
+		for j, coef := range warm {
+			intercepts[a] -= coef * xMeans[j]
+		}
+	}
+	return
+}
+
+func (cv *ElasticNetCV) Fit() {
+	cols, _, _, yCentered := centeredColumns(cv.X, cv.Y, cv.FitIntercept)
+	maxAlpha := alphaMax(cols, yCentered, cv.L1Ratio)
+	cv.Alphas = crossval.LogSpace(maxAlpha, maxAlpha*cv.Eps, cv.NAlphas)
+
+	folds := crossval.KFold(len(cv.Y), cv.NFolds, cv.rng)
+
+	foldMSE := make([][]float64, len(cv.Alphas))
+	for a := range foldMSE {
+		foldMSE[a] = make([]float64, len(folds))
+	}
+
+	for f, fold := range folds {
+		trainX := make([][]float64, len(fold.Train))
+		trainY := make([]float64, len(fold.Train))
+		for i, idx := range fold.Train {
+			trainX[i] = cv.X[idx]
+			trainY[i] = cv.Y[idx]
+		}
+
+		coefs, intercepts := cv.fitPath(trainX, trainY)
+		for a := range cv.Alphas {
+			sse := 0.0
+			for _, idx := range fold.Test {
+				pred := intercepts[a]
+				for j, coef := range coefs[a] {
+					pred += coef * cv.X[idx][j]
+				}
+				sse += (pred - cv.Y[idx]) * (pred - cv.Y[idx])
+			}
+			foldMSE[a][f] = sse / float64(len(fold.Test))
+		}
+	}
+
+	coefs, intercepts := cv.fitPath(cv.X, cv.Y)
+
+	cv.Path = make([]crossval.PathPoint, len(cv.Alphas))
+	best := 0
+	for a, alpha := range cv.Alphas {
+		nonZero := 0
+		for _, coef := range coefs[a] {
+			if coef != 0 {
+				nonZero++
+			}
+		}
+		mean, std := stat.MeanStdDev(foldMSE[a], nil)
+		cv.Path[a] = crossval.PathPoint{
+			Alpha:         alpha,
+			Coefs:         coefs[a],
+			Intercept:     intercepts[a],
+			NonZero:       nonZero,
+			ValidationMSE: mean,
+			ValidationStd: std,
+		}
+		if mean < cv.Path[best].ValidationMSE {
+			best = a
+		}
+	}
+
+	cv.BestAlpha = cv.Alphas[best]
+	cv.Coefs = coefs[best]
+	cv.Intercept = intercepts[best]
+	cv.ZeroCoefs = make([]int, 0)
+	for j, coef := range cv.Coefs {
+		if coef == 0 {
+			cv.ZeroCoefs = append(cv.ZeroCoefs, j)
+		}
+	}
+
+	preds := make([]float64, len(cv.Y))
+	for i, row := range cv.X {
+		preds[i] = cv.Predict(row)
+	}
+	cv.Metrics = metrics.Evaluate(cv.Y, preds)
+}
+
+func (cv *ElasticNetCV) Predict(x []float64) float64 {
+	if len(x) != len(cv.Coefs) {
+		panic("Input feature length does not match number of coefficients")
+	}
+
+	pred := cv.Intercept
+	for i, coef := range cv.Coefs {
+		pred += coef * x[i]
+	}
+	return pred
+}
+
+func (cv *ElasticNetCV) GetMetrics() metrics.Metrics {
+	return cv.Metrics
+}
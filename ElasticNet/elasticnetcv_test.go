@@ -0,0 +1,75 @@
+package ElasticNet
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// sparseLinear draws y = 3 x0 - 2 x1 + x2 + noise over nFeatures standard normal features.
+func sparseLinear(nRows, nFeatures int, seed int64) ([][]float64, []float64) {
+	rng := rand.New(rand.NewSource(seed))
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = make([]float64, nFeatures)
+		for j := range x[i] {
+			x[i][j] = rng.NormFloat64()
+		}
+		y[i] = 5 + 3*x[i][0] - 2*x[i][1] + x[i][2] + 2*rng.NormFloat64()
+	}
+	return x, y
+}
+
+func TestCVBestAlpha(t *testing.T) {
+	x, y := sparseLinear(50, 20, 1)
+	for name, l1Ratio := range map[string]float64{"lasso": 1, "elasticnet": 0.5} {
+		t.Run(name, func(t *testing.T) {
+			seed := int64(1)
+			cv := NewElasticNetCV(x, y, l1Ratio, 30, 5, 10000, 1e-8, true, &seed).(*ElasticNetCV)
+			cv.Fit()
+
+			if cv.Path[0].NonZero != 0 {
+				t.Errorf("%d non-zero coefficients at the largest alpha %v, want none", cv.Path[0].NonZero, cv.Path[0].Alpha)
+			}
+			best := 0
+			for a, point := range cv.Path {
+				if point.ValidationMSE < cv.Path[best].ValidationMSE {
+					best = a
+				}
+			}
+			if cv.BestAlpha != cv.Path[best].Alpha || !slices.Equal(cv.Coefs, cv.Path[best].Coefs) {
+				t.Errorf("best alpha %v, want %v of the least validation MSE", cv.BestAlpha, cv.Path[best].Alpha)
+			}
+			if best == 0 || best == len(cv.Path)-1 {
+				t.Errorf("best alpha %v at the edge of the grid", cv.BestAlpha)
+			}
+		})
+	}
+}
+
+// Cross-validation picks the alpha that predicts best rather than the one that selects the true features, so a few
+// irrelevant features may keep small coefficients, but most are zeroed and none of the relevant ones.
+func TestLassoCVZeroesIrrelevant(t *testing.T) {
+	x, y := sparseLinear(200, 20, 1)
+	seed := int64(1)
+	cv := NewLassoCV(x, y, 50, 5, 10000, 1e-8, true, &seed).(*ElasticNetCV)
+	cv.Fit()
+
+	var zero []int
+	for j, coef := range cv.Coefs {
+		if coef == 0 {
+			zero = append(zero, j)
+		}
+		if j >= 3 && math.Abs(coef) > 0.2 {
+			t.Errorf("irrelevant feature %d has coefficient %v", j, coef)
+		}
+	}
+	if !slices.Equal(cv.ZeroCoefs, zero) {
+		t.Errorf("ZeroCoefs %v, want the zero coefficients %v", cv.ZeroCoefs, zero)
+	}
+	if len(zero) < 17/2 || zero[0] < 3 {
+		t.Errorf("zeroed features %v, want most of the irrelevant features 3 to 19 and none of 0 to 2", zero)
+	}
+}
@@ -0,0 +1,212 @@
+package Ensemble
+
+import (
+	"GoML/metrics"
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Losses of AdaBoost.R2, applied to the absolute error of each row divided by the largest one.
+const (
+	AdaBoostLinear      = "linear"      // e
+	AdaBoostSquare      = "square"      // e^2
+	AdaBoostExponential = "exponential" // 1 - exp(-e)
+)
+
+// AdaBoost is the AdaBoost.R2 ensemble of Drucker (1997). Every stage is fitted to the rows weighted by how badly the
+// previous stages predicted them, gets a confidence ln(1 / beta) from its weighted loss, and the ensemble predicts the
+// weighted median of the stage predictions.
+//
+// Stages are fitted with WeightedFactory when it is set (e.g. OLS.NewWLS), and otherwise with Factory on a weighted
+// resample of the rows.
+type AdaBoost struct {
+	X [][]float64
+	Y []float64
+
+	Estimators      []Estimator
+	Factory         func(x [][]float64, y []float64) Estimator
+	WeightedFactory func(x [][]float64, y []float64, weights []float64) Estimator
+	NEstimators     int
+	LearningRate    float64
+	Loss            string // AdaBoostLinear, AdaBoostSquare or AdaBoostExponential
+
+	// Fit results
+	EstimatorWeights []float64 // ln(1 / beta) of each stage, scaled by LearningRate
+	EstimatorErrors  []float64 // Weighted average loss of each stage
+	SampleWeights    []float64 // Row weights after the last stage
+
+	Metrics metrics.Metrics
+
+	// Random State
+	RandSeed *int64
+	rng      *rand.Rand
+}
+
+func NewAdaBoost(estimatorFactory func(x [][]float64, y []float64) Estimator, nEstimators int, x [][]float64, y []float64, learningRate float64, loss string, randSeed *int64) Estimator {
+	if nEstimators <= 0 {
+		panic("NEstimators must be positive")
+	}
+	if learningRate <= 0 {
+		panic("LearningRate must be positive")
+	}
+	if loss != AdaBoostLinear && loss != AdaBoostSquare && loss != AdaBoostExponential {
+		panic("Loss must be linear, square or exponential")
+	}
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+
+	return &AdaBoost{
+		X:            x,
+		Y:            y,
+		Factory:      estimatorFactory,
+		NEstimators:  nEstimators,
+		LearningRate: learningRate,
+		Loss:         loss,
+		RandSeed:     randSeed,
+		rng:          rand.New(rand.NewSource(*randSeed)),
+	}
+}
+
+func NewDefaultAdaBoost(estimatorFactory func(x [][]float64, y []float64) Estimator, nEstimators int, x [][]float64, y []float64) Estimator {
+	return NewAdaBoost(estimatorFactory, nEstimators, x, y, 1.0, AdaBoostLinear, nil)
+}
+
+func (a *AdaBoost) loss(e float64) float64 {
+	switch a.Loss {
+	case AdaBoostSquare:
+		return e * e
+	case AdaBoostExponential:
+		return 1 - math.Exp(-e)
+	}
+	return e
+}
+
+// fitStage fits one estimator to the rows weighted by weights, which sum to 1.
+func (a *AdaBoost) fitStage(weights []float64) Estimator {
+	if a.WeightedFactory != nil {
+		// Weighted estimators expect weights around 1, and tiny weights must stay positive
+		scaled := make([]float64, len(weights))
+		for i, w := range weights {
+			scaled[i] = max(w*float64(len(weights)), 1e-12)
+		}
+		estimator := a.WeightedFactory(a.X, a.Y, scaled)
+		estimator.Fit()
+		return estimator
+	}
+
+	cumulative := make([]float64, len(weights))
+	sum := 0.0
+	for i, w := range weights {
+		sum += w
+		cumulative[i] = sum
+	}
+	sampleX := make([][]float64, len(a.Y))
+	sampleY := make([]float64, len(a.Y))
+	for i := range sampleY {
+		row := min(sort.SearchFloat64s(cumulative, a.rng.Float64()*sum), len(a.Y)-1)
+		sampleX[i], sampleY[i] = a.X[row], a.Y[row]
+	}
+	estimator := a.Factory(sampleX, sampleY)
+	estimator.Fit()
+	return estimator
+}
+
+func (a *AdaBoost) Fit() {
+	nRows := len(a.Y)
+	weights := make([]float64, nRows)
+	for i := range weights {
+		weights[i] = 1 / float64(nRows)
+	}
+
+	a.Estimators = make([]Estimator, 0, a.NEstimators)
+	a.EstimatorWeights = make([]float64, 0, a.NEstimators)
+	a.EstimatorErrors = make([]float64, 0, a.NEstimators)
+	errs := make([]float64, nRows)
+	for stage := 0; stage < a.NEstimators; stage++ {
+		estimator := a.fitStage(weights)
+
+		maxErr := 0.0
+		for i, row := range a.X {
+			errs[i] = math.Abs(a.Y[i] - estimator.Predict(row))
+			maxErr = max(maxErr, errs[i])
+		}
+		// A perfect fit needs no further stages
+		if maxErr == 0 {
+			a.Estimators = append(a.Estimators, estimator)
+			a.EstimatorWeights = append(a.EstimatorWeights, 1.0)
+			a.EstimatorErrors = append(a.EstimatorErrors, 0.0)
+			break
+		}
+
+		avgLoss := 0.0
+		for i := range errs {
+			errs[i] = a.loss(errs[i] / maxErr)
+			avgLoss += weights[i] * errs[i]
+		}
+		// A stage no better than chance ends boosting, only the first one is kept so there is a prediction
+		if avgLoss >= 0.5 {
+			if stage == 0 {
+				a.Estimators = append(a.Estimators, estimator)
+				a.EstimatorWeights = append(a.EstimatorWeights, 1.0)
+				a.EstimatorErrors = append(a.EstimatorErrors, avgLoss)
+			}
+			break
+		}
+
+		beta := avgLoss / (1 - avgLoss)
+		a.Estimators = append(a.Estimators, estimator)
+		a.EstimatorWeights = append(a.EstimatorWeights, a.LearningRate*math.Log(1/beta))
+		a.EstimatorErrors = append(a.EstimatorErrors, avgLoss)
+
+		// Well predicted rows lose weight, rows with the largest error keep theirs
+		sum := 0.0
+		for i := range weights {
+			weights[i] *= math.Pow(beta, (1-errs[i])*a.LearningRate)
+			sum += weights[i]
+		}
+		for i := range weights {
+			weights[i] /= sum
+		}
+	}
+	a.SampleWeights = weights
+
+	preds := make([]float64, nRows)
+	for i, row := range a.X {
+		preds[i] = a.Predict(row)
+	}
+	a.Metrics = metrics.Evaluate(a.Y, preds)
+}
+
+// Predict returns the weighted median of the stage predictions: the smallest prediction whose cumulative weight
+// reaches half of the total.
+func (a *AdaBoost) Predict(x []float64) float64 {
+	type vote struct{ pred, weight float64 }
+	votes := make([]vote, len(a.Estimators))
+	total := 0.0
+	for i, est := range a.Estimators {
+		votes[i] = vote{est.Predict(x), a.EstimatorWeights[i]}
+		total += a.EstimatorWeights[i]
+	}
+	slices.SortFunc(votes, func(u, v vote) int {
+		return cmp.Compare(u.pred, v.pred)
+	})
+
+	cumulative := 0.0
+	for _, v := range votes {
+		cumulative += v.weight
+		if cumulative >= total/2 {
+			return v.pred
+		}
+	}
+	return votes[len(votes)-1].pred
+}
+
+func (a *AdaBoost) GetMetrics() metrics.Metrics {
+	return a.Metrics
+}
@@ -0,0 +1,173 @@
+package Ensemble_test
+
+import (
+	"GoML/Ensemble"
+	"GoML/OLS"
+	"GoML/metrics"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// constant predicts the same value everywhere.
+type constant float64
+
+func (c constant) Fit()                        {}
+func (c constant) Predict([]float64) float64   { return float64(c) }
+func (c constant) GetMetrics() metrics.Metrics { return metrics.Metrics{} }
+
+// predictor predicts with a fixed function of x.
+type predictor func(x []float64) float64
+
+func (p predictor) Fit()                        {}
+func (p predictor) Predict(x []float64) float64 { return p(x) }
+func (p predictor) GetMetrics() metrics.Metrics { return metrics.Metrics{} }
+
+func constantFactory(c float64) func(x [][]float64, y []float64) Ensemble.Estimator {
+	return func(x [][]float64, y []float64) Ensemble.Estimator { return constant(c) }
+}
+
+func TestAdaBoostWeightedMedian(t *testing.T) {
+	a := &Ensemble.AdaBoost{
+		Estimators:       []Ensemble.Estimator{constant(3), constant(1), constant(2), constant(5)},
+		EstimatorWeights: []float64{0.1, 0.2, 0.3, 0.4},
+	}
+	// Sorted: 1 (0.2), 2 (0.3), 3 (0.1), 5 (0.4). The cumulative weight reaches half of 1 at 2.
+	if got := a.Predict(nil); got != 2 {
+		t.Errorf("weighted median %v, want 2", got)
+	}
+	a.EstimatorWeights = []float64{0.1, 0.2, 0.3, 1.4}
+	if got := a.Predict(nil); got != 5 {
+		t.Errorf("weighted median %v, want 5 once its weight exceeds the others combined", got)
+	}
+	a.Estimators, a.EstimatorWeights = []Ensemble.Estimator{constant(2), constant(1)}, []float64{1, 1}
+	if got := a.Predict(nil); got != 1 {
+		t.Errorf("weighted median of a tie %v, want the smaller prediction 1", got)
+	}
+}
+
+// A constant stage predicting 0 on y = [0 0 0 1 2] has relative errors [0 0 0 0.5 1].
+func TestAdaBoostLosses(t *testing.T) {
+	x := [][]float64{{0}, {1}, {2}, {3}, {4}}
+	y := []float64{0, 0, 0, 1, 2}
+	errs := []float64{0, 0, 0, 0.5, 1}
+	losses := map[string]func(e float64) float64{
+		Ensemble.AdaBoostLinear:      func(e float64) float64 { return e },
+		Ensemble.AdaBoostSquare:      func(e float64) float64 { return e * e },
+		Ensemble.AdaBoostExponential: func(e float64) float64 { return 1 - math.Exp(-e) },
+	}
+	for name, loss := range losses {
+		t.Run(name, func(t *testing.T) {
+			seed := int64(1)
+			a := Ensemble.NewAdaBoost(constantFactory(0), 1, x, y, 0.5, name, &seed).(*Ensemble.AdaBoost)
+			a.WeightedFactory = func(x [][]float64, y []float64, weights []float64) Ensemble.Estimator { return constant(0) }
+			a.Fit()
+
+			avgLoss := 0.0
+			for _, e := range errs {
+				avgLoss += loss(e) / float64(len(errs))
+			}
+			beta := avgLoss / (1 - avgLoss)
+			if math.Abs(a.EstimatorErrors[0]-avgLoss) > 1e-12 {
+				t.Errorf("stage error %v, want %v", a.EstimatorErrors[0], avgLoss)
+			}
+			if want := 0.5 * math.Log(1/beta); math.Abs(a.EstimatorWeights[0]-want) > 1e-12 {
+				t.Errorf("stage weight %v, want %v", a.EstimatorWeights[0], want)
+			}
+
+			sum := 0.0
+			for _, e := range errs {
+				sum += math.Pow(beta, (1-loss(e))*0.5)
+			}
+			for i, e := range errs {
+				if want := math.Pow(beta, (1-loss(e))*0.5) / sum; math.Abs(a.SampleWeights[i]-want) > 1e-12 {
+					t.Errorf("row %d weight %v, want %v", i, a.SampleWeights[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestAdaBoostEarlyExits(t *testing.T) {
+	x := [][]float64{{0}, {1}, {2}, {3}}
+	seed := int64(1)
+
+	t.Run("perfect fit", func(t *testing.T) {
+		a := Ensemble.NewAdaBoost(constantFactory(5), 10, x, []float64{5, 5, 5, 5}, 1, Ensemble.AdaBoostLinear, &seed).(*Ensemble.AdaBoost)
+		a.Fit()
+		if len(a.Estimators) != 1 || a.EstimatorWeights[0] != 1 || a.EstimatorErrors[0] != 0 {
+			t.Errorf("kept %d stages with weights %v and errors %v, want the single perfect stage", len(a.Estimators), a.EstimatorWeights, a.EstimatorErrors)
+		}
+	})
+
+	y := []float64{0, 1, 2, 3}
+	t.Run("first stage worse than chance", func(t *testing.T) {
+		// Relative errors [1 0.98 0.97 0.96] average well above 0.5
+		a := Ensemble.NewAdaBoost(constantFactory(-100), 10, x, y, 1, Ensemble.AdaBoostLinear, &seed).(*Ensemble.AdaBoost)
+		a.Fit()
+		if len(a.Estimators) != 1 || a.EstimatorWeights[0] != 1 || a.EstimatorErrors[0] < 0.5 {
+			t.Errorf("kept %d stages with weights %v and errors %v, want only the first stage", len(a.Estimators), a.EstimatorWeights, a.EstimatorErrors)
+		}
+		if got := a.Predict(x[0]); got != -100 {
+			t.Errorf("predicts %v, want the first stage's -100", got)
+		}
+	})
+
+	t.Run("later stage worse than chance", func(t *testing.T) {
+		// Stage 1 misses the last row, stage 2 only the first, stage 3 every row by far
+		y := []float64{0, 0, 0, 3}
+		stages := []Ensemble.Estimator{
+			constant(0),
+			predictor(func(x []float64) float64 { return y[int(x[0])] + max(0, 1-x[0]) }),
+			constant(-100),
+		}
+		nFits := 0
+		factory := func(x [][]float64, y []float64) Ensemble.Estimator {
+			nFits++
+			return stages[nFits-1]
+		}
+		a := Ensemble.NewAdaBoost(factory, 10, x, y, 1, Ensemble.AdaBoostLinear, &seed).(*Ensemble.AdaBoost)
+		a.Fit()
+		if nFits != 3 || len(a.Estimators) != 2 {
+			t.Errorf("fitted %d stages and kept %d, want 3 fitted and the 2 better than chance kept", nFits, len(a.Estimators))
+		}
+	})
+}
+
+// With uniform initial weights and many rows, fitting a stage on a weighted resample approximates fitting it with
+// the weights, so both paths reach about the same stage errors and predictions.
+func TestAdaBoostReweightingMatchesResampling(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	x := make([][]float64, 2000)
+	y := make([]float64, len(x))
+	for i := range x {
+		x[i] = []float64{10 * rng.Float64()}
+		y[i] = 1 + 2*x[i][0] + rng.NormFloat64()
+	}
+	seed := int64(1)
+	resampled := Ensemble.NewAdaBoost(OLS.NewOLS, 5, x, y, 1, Ensemble.AdaBoostLinear, &seed).(*Ensemble.AdaBoost)
+	resampled.Fit()
+	reweighted := Ensemble.NewAdaBoost(OLS.NewOLS, 5, x, y, 1, Ensemble.AdaBoostLinear, &seed).(*Ensemble.AdaBoost)
+	reweighted.WeightedFactory = OLS.NewWLS
+	reweighted.Fit()
+
+	if len(resampled.Estimators) != len(reweighted.Estimators) {
+		t.Fatalf("resampling kept %d stages, reweighting %d", len(resampled.Estimators), len(reweighted.Estimators))
+	}
+	for stage := range resampled.EstimatorErrors {
+		if diff := math.Abs(resampled.EstimatorErrors[stage] - reweighted.EstimatorErrors[stage]); diff > 0.01 {
+			t.Errorf("stage %d: resampling error %v, reweighting error %v", stage, resampled.EstimatorErrors[stage], reweighted.EstimatorErrors[stage])
+		}
+	}
+	for _, v := range []float64{1, 5, 9} {
+		if diff := math.Abs(resampled.Predict([]float64{v}) - reweighted.Predict([]float64{v})); diff > 0.2 {
+			t.Errorf("x = %v: resampling predicts %v, reweighting %v", v, resampled.Predict([]float64{v}), reweighted.Predict([]float64{v}))
+		}
+	}
+	// Reweighting fits the first stage on every row with equal weights, which is plain OLS
+	ols := OLS.NewOLS(x, y)
+	ols.Fit()
+	if got, want := reweighted.Estimators[0].Predict([]float64{5}), ols.Predict([]float64{5}); math.Abs(got-want) > 1e-9 {
+		t.Errorf("first reweighted stage predicts %v, OLS %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package Ensemble
 
 import (
 	"GoML/metrics"
+	"math"
 	"math/rand"
 	"slices"
 	"time"
@@ -13,7 +14,12 @@ type Bagged struct {
 	//Ensemble Components
 	Estimators []Estimator
 	Bags       []Sample
-	weights    []float64
+
+	// Sampling, fixed at construction
+	MaxSamples        float64 // Fraction of the rows drawn for each bag
+	Bootstrap         bool    // Draw rows with replacement; without it, MaxSamples 1 fits every estimator on every row
+	MaxFeatures       float64 // Fraction of the features drawn for each bag
+	BootstrapFeatures bool    // Draw features with replacement
 
 	// Raw Data
 	X [][]float64
@@ -21,24 +27,52 @@ type Bagged struct {
 
 	//Metrics
 	FitMetrics metrics.Metrics
-	OOBMetrics metrics.Metrics
+	OOBMetrics metrics.Metrics // Metrics of the out-of-bag predictions, over the rows at least one bag left out
+	OOBScore   float64         // R2 of the out-of-bag predictions, accuracy for a BaggedClassifier
+
+	oobPredictions []float64
+
+	// Goroutines fitting and predicting with the estimators, 0 or 1 runs serially and -1 uses every CPU. Set it
+	// before Fit; the bags are always drawn serially by the constructor.
+	NJobs int
 
 	// Random State
 	RandSeed *int64
 	rng      *rand.Rand
 }
 
+// NewBagged creates a Bagged ensemble of nEstimators fitted on bootstrap samples of the rows. When the factory builds
+// Classifiers the result is a *BaggedClassifier, which embeds *Bagged, so callers must type-assert to
+// *BaggedClassifier (or to Classifier) rather than *Bagged.
 func NewBagged(estimatorFactory func(x [][]float64, y []float64) Estimator, nEstimators int, x [][]float64, y []float64, randSeed *int64) Estimator {
+	return NewSubsampledBagged(estimatorFactory, nEstimators, x, y, 1, true, 1, false, randSeed)
+}
+
+// NewSubsampledBagged creates a Bagged ensemble whose bags hold a maxSamples fraction of the rows, drawn with or
+// without replacement, and a maxFeatures fraction of the features. Drawing rows without replacement is pasting,
+// drawing only features is random subspaces and drawing both is random patches. Like NewBagged it returns a
+// *BaggedClassifier for classifier factories.
+func NewSubsampledBagged(estimatorFactory func(x [][]float64, y []float64) Estimator, nEstimators int, x [][]float64, y []float64, maxSamples float64, bootstrap bool, maxFeatures float64, bootstrapFeatures bool, randSeed *int64) Estimator {
+	if nEstimators <= 0 {
+		panic("NEstimators must be positive")
+	}
+	if maxSamples <= 0 || maxSamples > 1 || maxFeatures <= 0 || maxFeatures > 1 {
+		panic("MaxSamples and MaxFeatures must be in (0, 1]")
+	}
 	if randSeed == nil {
 		tNow := time.Now().UnixNano()
 		randSeed = &tNow
 	}
 
 	b := &Bagged{
-		X:        x,
-		Y:        y,
-		RandSeed: randSeed,
-		rng:      rand.New(rand.NewSource(*randSeed)),
+		X:                 x,
+		Y:                 y,
+		MaxSamples:        maxSamples,
+		Bootstrap:         bootstrap,
+		MaxFeatures:       maxFeatures,
+		BootstrapFeatures: bootstrapFeatures,
+		RandSeed:          randSeed,
+		rng:               rand.New(rand.NewSource(*randSeed)),
 	}
 	b.setBags(nEstimators)
 	estimators := make([]Estimator, nEstimators)
@@ -47,6 +81,9 @@ func NewBagged(estimatorFactory func(x [][]float64, y []float64) Estimator, nEst
 	}
 	b.Estimators = estimators
 
+	if _, ok := estimators[0].(Classifier); ok {
+		return &BaggedClassifier{Bagged: b, Classes: metrics.UniqueClasses(y)}
+	}
 	return b
 }
 
@@ -54,109 +91,142 @@ func NewDefaultBagged(estimatorFactory func(x [][]float64, y []float64) Estimato
 	return NewBagged(estimatorFactory, nEstimators, x, y, nil)
 }
 
-func (b *Bagged) bootstrapSample() Sample {
+func (b *Bagged) bootstrapSample(rng *rand.Rand) Sample {
 	nRows := len(b.Y)
+	nFeatures := len(b.X[0])
+	nSamples := max(1, int(b.MaxSamples*float64(nRows)))
+
+	var rows []int
+	if b.Bootstrap {
+		rows = make([]int, nSamples)
+		for i := range rows {
+			rows[i] = rng.Intn(nRows)
+		}
+	} else {
+		rows = rng.Perm(nRows)[:nSamples]
+		slices.Sort(rows)
+	}
 
-	feature := make([][]float64, nRows)
-	label := make([]float64, nRows)
-	indices := make([]int, 0, nRows)
-	for i := 0; i < nRows; i++ {
-		idx := b.rng.Intn(nRows)
-		feature[i] = b.X[idx]
+	var features []int
+	if b.BootstrapFeatures {
+		features = make([]int, max(1, int(b.MaxFeatures*float64(nFeatures))))
+		for j := range features {
+			features[j] = rng.Intn(nFeatures)
+		}
+		slices.Sort(features)
+	} else if b.MaxFeatures < 1 {
+		features = rng.Perm(nFeatures)[:max(1, int(b.MaxFeatures*float64(nFeatures)))]
+		slices.Sort(features)
+	}
+
+	feature := make([][]float64, nSamples)
+	label := make([]float64, nSamples)
+	inBag := make([]bool, nRows)
+	for i, idx := range rows {
+		feature[i] = project(b.X[idx], features)
 		label[i] = b.Y[idx]
-		indices = append(indices, idx)
+		inBag[idx] = true
 	}
 
 	oobIndices := make(map[int]bool)
 	for i := 0; i < nRows; i++ {
-		oobIndices[i] = !slices.Contains(indices, i)
+		oobIndices[i] = !inBag[i]
 	}
 
-	return Sample{X: feature, Y: label, OOBIndices: oobIndices}
+	return Sample{X: feature, Y: label, OOBIndices: oobIndices, Features: features}
 }
 
+// setBags draws every bag from its own generator, seeded in order from the ensemble RandSeed. The bags are drawn
+// serially by the constructor, before NJobs can be set, and do not depend on it.
 func (b *Bagged) setBags(nEstimators int) {
-	bags := make([]Sample, nEstimators)
-	for i := 0; i < nEstimators; i++ {
-		bags[i] = b.bootstrapSample()
+	b.Bags = make([]Sample, nEstimators)
+	for i := range b.Bags {
+		b.Bags[i] = b.bootstrapSample(rand.New(rand.NewSource(b.rng.Int63())))
 	}
-	b.Bags = bags
 }
 
+// GetOOB returns, for every estimator, the rows its bag left out in row order, projected on the bag features.
 func (b *Bagged) GetOOB() []Sample {
 	samples := make([]Sample, len(b.Estimators))
 	for i := 0; i < len(b.Estimators); i++ {
 		oobX := make([][]float64, 0)
 		oobY := make([]float64, 0)
-
-		for idx, isOOB := range b.Bags[i].OOBIndices {
-			if isOOB {
-				oobX = append(oobX, b.X[idx])
+		for idx := range b.Y {
+			if b.Bags[i].OOBIndices[idx] {
+				oobX = append(oobX, project(b.X[idx], b.Bags[i].Features))
 				oobY = append(oobY, b.Y[idx])
 			}
-			s := Sample{
-				X:          oobX,
-				Y:          oobY,
-				OOBIndices: b.Bags[i].OOBIndices,
-			}
-			samples[i] = s
+		}
+		samples[i] = Sample{
+			X:          oobX,
+			Y:          oobY,
+			OOBIndices: b.Bags[i].OOBIndices,
 		}
 	}
 	return samples
 }
 
 func (b *Bagged) Fit() {
-	oobEval := make([]float64, len(b.Estimators))
+	parallelFor(len(b.Estimators), b.NJobs, func(i int) {
+		b.Estimators[i].Fit()
+	})
 
-	for _, estimator := range b.Estimators {
-		estimator.Fit()
-	}
-	oob := b.GetOOB()
-	evalSetOOB := make([]metrics.Metrics, len(b.Estimators))
-	for i, sample := range oob {
-		preds := make([]float64, len(sample.Y))
-		for row, x := range sample.X {
-			preds[row] = b.Estimators[i].Predict(x)
+	nRows := len(b.Y)
+	predsFit := make([]float64, nRows)
+	b.oobPredictions = make([]float64, nRows)
+	parallelFor(nRows, b.NJobs, func(i int) {
+		sum, oobSum, nOOB := 0.0, 0.0, 0
+		for e, estimator := range b.Estimators {
+			pred := estimator.Predict(project(b.X[i], b.Bags[e].Features))
+			sum += pred
+			if b.Bags[e].OOBIndices[i] {
+				oobSum += pred
+				nOOB++
+			}
+		}
+		predsFit[i] = sum / float64(len(b.Estimators))
+
+		b.oobPredictions[i] = math.NaN()
+		if nOOB > 0 {
+			b.oobPredictions[i] = oobSum / float64(nOOB)
+		}
+	})
+	b.FitMetrics = metrics.Evaluate(b.Y, predsFit)
+
+	oobY := make([]float64, 0, nRows)
+	oobPreds := make([]float64, 0, nRows)
+	for i, pred := range b.oobPredictions {
+		if !math.IsNaN(pred) {
+			oobY = append(oobY, b.Y[i])
+			oobPreds = append(oobPreds, pred)
 		}
-		evalSetOOB[i] = metrics.Evaluate(sample.Y, preds)
-		oobEval[i] = 1 / (evalSetOOB[i].RMSE + 1e-8)
-	}
-	weights := make([]float64, len(oobEval))
-	sumMetric := 0.0
-	for _, metric := range oobEval {
-		sumMetric += metric
-	}
-	for estimator, metric := range oobEval {
-		weights[estimator] = metric / sumMetric
 	}
-	b.weights = weights
-
-	metricsOOB := metrics.Metrics{}
-	for i, metric := range evalSetOOB {
-		metricsOOB.R2 += metric.R2 * weights[i]
-		metricsOOB.RMSE += metric.RMSE * weights[i]
-		metricsOOB.MSE += metric.MSE * weights[i]
-		metricsOOB.MAE += metric.MAE * weights[i]
-		metricsOOB.MAPE += metric.MAPE * weights[i]
+	if len(oobY) > 0 {
+		b.OOBMetrics = metrics.Evaluate(oobY, oobPreds)
+		b.OOBScore = b.OOBMetrics.R2
 	}
+}
 
-	predsFit := make([]float64, len(b.Y))
-	for i := range b.Y {
-		predsFit[i] = b.Predict(b.X[i])
-	}
-	metricsFit := metrics.Evaluate(b.Y, predsFit)
+// OOBPredictions returns the out-of-bag prediction of every training row: the average of the estimators whose bag
+// left the row out. Rows every bag drew are NaN.
+func (b *Bagged) OOBPredictions() []float64 {
+	return b.oobPredictions
+}
 
-	b.FitMetrics = metricsFit
-	b.OOBMetrics = metricsOOB
+func (b *Bagged) Predict(x []float64) float64 {
+	return b.predict(x, b.NJobs)
+}
 
+func (b *Bagged) predict(x []float64, nJobs int) float64 {
+	return stat.Mean(b.estimatorPredictions(x, nJobs), nil)
 }
 
-func (b *Bagged) Predict(x []float64) float64 {
+func (b *Bagged) estimatorPredictions(x []float64, nJobs int) []float64 {
 	preds := make([]float64, len(b.Estimators))
-	for i, estimator := range b.Estimators {
-		preds[i] = estimator.Predict(x)
-	}
-	return stat.Mean(preds, b.weights)
+	parallelFor(len(b.Estimators), nJobs, func(i int) {
+		preds[i] = b.Estimators[i].Predict(project(x, b.Bags[i].Features))
+	})
+	return preds
 }
 
 func (b *Bagged) GetMetrics() metrics.Metrics {
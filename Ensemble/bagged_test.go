@@ -0,0 +1,79 @@
+package Ensemble_test
+
+import (
+	"GoML/DecTree"
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"GoML/parser"
+	"math"
+	"slices"
+	"testing"
+)
+
+func fitBagged(nJobs int) *Ensemble.Bagged {
+	data := parser.LoadData("../test_data.csv", ",", true, 13)
+	treeSeed, seed := int64(2), int64(1)
+	factory := func(x [][]float64, y []float64) Ensemble.Estimator {
+		return DecTree.NewDecTree(x, y, 4, 2, 1, &treeSeed, nil)
+	}
+	bagged := Ensemble.NewSubsampledBagged(factory, 25, data.X, data.Y, 1, true, 0.5, false, &seed).(*Ensemble.Bagged)
+	bagged.NJobs = nJobs
+	bagged.Fit()
+	return bagged
+}
+
+// equalNaN compares element-wise, treating two NaNs (rows every bag drew) as equal.
+func equalNaN(a, b []float64) bool {
+	return slices.EqualFunc(a, b, func(x, y float64) bool {
+		return x == y || (math.IsNaN(x) && math.IsNaN(y))
+	})
+}
+
+func TestBaggedNJobsDeterministic(t *testing.T) {
+	serial, parallel := fitBagged(1), fitBagged(4)
+
+	if !equalNaN(serial.OOBPredictions(), parallel.OOBPredictions()) {
+		t.Errorf("out-of-bag predictions differ:\n%v\n%v", serial.OOBPredictions(), parallel.OOBPredictions())
+	}
+	if serial.OOBScore != parallel.OOBScore || serial.FitMetrics.RMSE != parallel.FitMetrics.RMSE {
+		t.Errorf("metrics differ: OOB score %v vs %v, RMSE %v vs %v", serial.OOBScore, parallel.OOBScore, serial.FitMetrics.RMSE, parallel.FitMetrics.RMSE)
+	}
+	for i, row := range serial.X {
+		if got, want := parallel.Predict(row), serial.Predict(row); got != want {
+			t.Errorf("row %d: NJobs 4 predicts %v, NJobs 1 %v", i, got, want)
+		}
+		if got, want := parallel.PredictWithUncertainty(row, 0.1), serial.PredictWithUncertainty(row, 0.1); got != want {
+			t.Errorf("row %d: NJobs 4 uncertainty %+v, NJobs 1 %+v", i, got, want)
+		}
+	}
+}
+
+// panicking fails to fit, like an estimator rejecting its bag.
+type panicking struct{}
+
+func (panicking) Fit()                        { panic("bad bag") }
+func (panicking) Predict([]float64) float64   { return 0 }
+func (panicking) GetMetrics() metrics.Metrics { return metrics.Metrics{} }
+
+func TestBaggedWorkerPanicPropagates(t *testing.T) {
+	data := parser.LoadData("../test_data.csv", ",", true, 13)
+	seed := int64(1)
+	nFits := 0
+	factory := func(x [][]float64, y []float64) Ensemble.Estimator {
+		nFits++
+		if nFits == 5 {
+			return panicking{}
+		}
+		return DecTree.NewDecTree(x, y, 2, 2, 1, &seed, nil)
+	}
+	bagged := Ensemble.NewBagged(factory, 10, data.X, data.Y, &seed).(*Ensemble.Bagged)
+	bagged.NJobs = 4
+
+	defer func() {
+		if r := recover(); r != "bad bag" {
+			t.Errorf("recovered %v, want the worker's panic", r)
+		}
+	}()
+	bagged.Fit()
+	t.Error("Fit returned despite a panicking estimator")
+}
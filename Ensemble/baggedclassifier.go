@@ -0,0 +1,118 @@
+package Ensemble
+
+import (
+	"GoML/metrics"
+	"math"
+	"slices"
+)
+
+// BaggedClassifier is a Bagged ensemble of classifiers, returned by NewBagged when the factory builds Classifiers.
+// It predicts the class with the highest average member probability; with decision tree classifiers as members
+// (and MaxFeatures set on them) it is a random forest classifier.
+type BaggedClassifier struct {
+	*Bagged
+	Classes []float64
+
+	FitClassificationMetrics metrics.ClassificationMetrics
+	OOBClassificationMetrics metrics.ClassificationMetrics // Each row is predicted by the members that did not see it
+}
+
+// memberProba returns the class probabilities of member e aligned to the ensemble classes, since a bootstrap
+// sample can miss some of them.
+func (bc *BaggedClassifier) memberProba(e int, x []float64) []float64 {
+	member := bc.Estimators[e].(Classifier)
+	proba := make([]float64, len(bc.Classes))
+	memberClasses := member.GetClasses()
+	for k, p := range member.PredictProba(project(x, bc.Bags[e].Features)) {
+		idx, _ := slices.BinarySearch(bc.Classes, memberClasses[k])
+		proba[idx] = p
+	}
+	return proba
+}
+
+func (bc *BaggedClassifier) Fit() {
+	parallelFor(len(bc.Estimators), bc.NJobs, func(i int) {
+		bc.Estimators[i].Fit()
+	})
+
+	nRows := len(bc.Y)
+	predsFit := make([]float64, nRows)
+	probaFit := make([][]float64, nRows)
+	oobProbaRows := make([][]float64, nRows) // nil for rows every member saw
+	parallelFor(nRows, bc.NJobs, func(i int) {
+		row := bc.X[i]
+		proba := make([]float64, len(bc.Classes))
+		oob := make([]float64, len(bc.Classes))
+		nOOB := 0
+		for e := range bc.Estimators {
+			memberProba := bc.memberProba(e, row)
+			for k, p := range memberProba {
+				proba[k] += p / float64(len(bc.Estimators))
+			}
+			if bc.Bags[e].OOBIndices[i] {
+				nOOB++
+				for k, p := range memberProba {
+					oob[k] += p
+				}
+			}
+		}
+		probaFit[i] = proba
+		predsFit[i] = bc.Classes[metrics.ArgMax(proba)]
+
+		if nOOB > 0 {
+			for k := range oob {
+				oob[k] /= float64(nOOB)
+			}
+			oobProbaRows[i] = oob
+		}
+	})
+
+	bc.oobPredictions = make([]float64, nRows)
+	oobY := make([]float64, 0, nRows)
+	oobPreds := make([]float64, 0, nRows)
+	oobProba := make([][]float64, 0, nRows)
+	for i, oob := range oobProbaRows {
+		bc.oobPredictions[i] = math.NaN()
+		if oob != nil {
+			bc.oobPredictions[i] = bc.Classes[metrics.ArgMax(oob)]
+			oobY = append(oobY, bc.Y[i])
+			oobPreds = append(oobPreds, bc.oobPredictions[i])
+			oobProba = append(oobProba, oob)
+		}
+	}
+
+	bc.FitMetrics = metrics.Evaluate(bc.Y, predsFit)
+	bc.FitClassificationMetrics = metrics.EvaluateClassifier(bc.Y, predsFit, probaFit, bc.Classes)
+	if len(oobY) > 0 {
+		bc.OOBMetrics = metrics.Evaluate(oobY, oobPreds)
+		bc.OOBClassificationMetrics = metrics.EvaluateClassifier(oobY, oobPreds, oobProba, bc.Classes)
+		bc.OOBScore = bc.OOBClassificationMetrics.Accuracy
+	}
+}
+
+// PredictProba averages the members' class probabilities, in GetClasses order.
+func (bc *BaggedClassifier) PredictProba(x []float64) []float64 {
+	proba := make([]float64, len(bc.Classes))
+	for e := range bc.Estimators {
+		for k, p := range bc.memberProba(e, x) {
+			proba[k] += p / float64(len(bc.Estimators))
+		}
+	}
+	return proba
+}
+
+func (bc *BaggedClassifier) PredictClass(x []float64) float64 {
+	return bc.Classes[metrics.ArgMax(bc.PredictProba(x))]
+}
+
+func (bc *BaggedClassifier) Predict(x []float64) float64 {
+	return bc.PredictClass(x)
+}
+
+func (bc *BaggedClassifier) GetClasses() []float64 {
+	return bc.Classes
+}
+
+func (bc *BaggedClassifier) GetClassificationMetrics() metrics.ClassificationMetrics {
+	return bc.FitClassificationMetrics
+}
@@ -0,0 +1,73 @@
+package Ensemble_test
+
+import (
+	"GoML/DecTree"
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// halves draws two features and the class of the half of [0, 1] the first one falls in, clear of the boundary.
+// With noisy set the classes are drawn at random instead, so that nothing can be learnt.
+func halves(rng *rand.Rand, nRows int, noisy bool) ([][]float64, []float64) {
+	x := make([][]float64, 0, nRows)
+	y := make([]float64, 0, nRows)
+	for len(x) < nRows {
+		x0 := rng.Float64()
+		if math.Abs(x0-0.5) < 0.05 {
+			continue
+		}
+		class := math.Floor(2 * x0)
+		if noisy {
+			class = float64(rng.Intn(2))
+		}
+		x = append(x, []float64{x0, rng.Float64()})
+		y = append(y, class)
+	}
+	return x, y
+}
+
+func treeClassifierFactory(x [][]float64, y []float64) Ensemble.Estimator {
+	seed := int64(1)
+	return DecTree.NewDecTreeClassifier(x, y, 10, 2, 1, "gini", &seed, nil)
+}
+
+func TestBaggedClassifierSeparable(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	x, y := halves(rng, 200, false)
+	seed := int64(1)
+	bc := Ensemble.NewBagged(treeClassifierFactory, 25, x, y, &seed).(*Ensemble.BaggedClassifier)
+	bc.Fit()
+
+	xTest, yTest := halves(rng, 200, false)
+	for i, row := range xTest {
+		proba := bc.PredictProba(row)
+		if math.Abs(proba[0]+proba[1]-1) > 1e-12 {
+			t.Fatalf("PredictProba(%v) = %v does not sum to 1", row, proba)
+		}
+		if got := bc.PredictClass(row); got != yTest[i] || got != bc.Classes[metrics.ArgMax(proba)] {
+			t.Fatalf("PredictClass(%v) = %v with probabilities %v, want %v", row, got, proba, yTest[i])
+		}
+	}
+	if bc.OOBScore != 1 || bc.OOBClassificationMetrics.Accuracy != 1 {
+		t.Errorf("OOB accuracy %v, want 1 on separable classes", bc.OOBScore)
+	}
+}
+
+// Deep trees fit random labels perfectly, but the out-of-bag predictions come from trees that did not see the row
+// and are right about half of the time.
+func TestBaggedClassifierOOBAccuracy(t *testing.T) {
+	x, y := halves(rand.New(rand.NewSource(1)), 400, true)
+	seed := int64(1)
+	bc := Ensemble.NewBagged(treeClassifierFactory, 25, x, y, &seed).(*Ensemble.BaggedClassifier)
+	bc.Fit()
+
+	if bc.FitClassificationMetrics.Accuracy < 0.95 {
+		t.Errorf("training accuracy %v, want the random labels memorized", bc.FitClassificationMetrics.Accuracy)
+	}
+	if math.Abs(bc.OOBScore-0.5) > 0.08 {
+		t.Errorf("OOB accuracy %v, want about 0.5 on random labels", bc.OOBScore)
+	}
+}
@@ -2,86 +2,330 @@ package Ensemble
 
 import (
 	"GoML/metrics"
-	"math"
+	"math/rand"
+	"slices"
+	"time"
 )
 
+// Boosted is a gradient boosting ensemble of any base estimator. It starts from the constant minimizing Loss and
+// then, for each of NEstimators stages, fits a base estimator to the pseudo-residuals of the current predictions,
+// scales it by the line search multiplier of Loss and adds it shrunk by LearningRate:
+// F_m(x) = F_{m-1}(x) + LearningRate * StepSizes[m] * h_m(x).
+// Stages whose estimator is a LeafIndexer (a decision tree) instead get a line search per leaf, Friedman's TreeBoost:
+// each leaf predicts the constant LeafValues[m][leaf] minimizing Loss over its rows. A single multiplier for the whole
+// stage stalls losses with sign-valued pseudo-residuals such as the quantile loss, as the row pinning the optimal
+// multiplier only ever approaches its kink and the same stage is refitted with ever smaller steps.
+//
+// With Subsample or ColsampleByTree below 1 it is stochastic gradient boosting: each stage is fitted on a random
+// fraction of the rows (drawn without replacement) and of the features, and the rows left out of a stage measure
+// its out-of-bag improvement.
+//
+// Boosting stops early once the loss on a validation set has not improved by more than Tol for NIterNoChange stages.
+// The validation set is ValidationX and ValidationY when given, or else a random ValidationFraction of the rows held
+// out of training.
 type Boosted struct {
 	X [][]float64
 	Y []float64
 
 	Estimators   []Estimator
 	Factory      func(x [][]float64, y []float64) Estimator
+	NEstimators  int
 	LearningRate float64
+	Loss         Loss // Squared error unless set before Fit
+
+	Subsample       float64 // Fraction of rows each stage is fitted on
+	ColsampleByTree float64 // Fraction of features each stage is fitted on
+
+	// Early stopping, disabled while NIterNoChange is 0
+	ValidationX        [][]float64
+	ValidationY        []float64
+	ValidationFraction float64 // Share of the rows held out when ValidationX is not given
+	NIterNoChange      int
+	Tol                float64
+
+	// Fit results
+	InitialPrediction float64
+	StepSizes         []float64   // Line search multiplier of each stage, before shrinkage (1 for stages with LeafValues)
+	LeafValues        [][]float64 // Per-leaf line search of each LeafIndexer stage, before shrinkage; nil for other stages
+	Features          [][]int     // Feature subset of each stage, nil when it saw every feature
+	OOBImprovement    []float64   // Loss decrease of each stage on the rows it was not fitted on, nil without row subsampling
+	TrainLoss         []float64   // Loss on the training rows after each stage
+	ValidationLoss    []float64   // Loss on the validation set after each stage, nil without one
 
 	Metrics metrics.Metrics
+
+	// Random State
+	RandSeed *int64
+	rng      *rand.Rand
 }
 
 func NewBoosted(estimatorFactory func(x [][]float64, y []float64) Estimator, nEstimators int, x [][]float64, y []float64, learningRate float64) Estimator {
-	estimators := make([]Estimator, nEstimators)
-	estimators[0] = estimatorFactory(x, y)
+	return NewStochasticBoosted(estimatorFactory, nEstimators, x, y, learningRate, 1, 1, nil)
+}
+
+// NewStochasticBoosted creates a Boosted ensemble whose stages are fitted on a random subsample fraction of the rows
+// and colsampleByTree fraction of the features.
+func NewStochasticBoosted(estimatorFactory func(x [][]float64, y []float64) Estimator, nEstimators int, x [][]float64, y []float64, learningRate, subsample, colsampleByTree float64, randSeed *int64) Estimator {
+	if nEstimators <= 0 {
+		panic("NEstimators must be positive")
+	}
+	if learningRate <= 0 {
+		panic("LearningRate must be positive")
+	}
+	if subsample <= 0 || subsample > 1 || colsampleByTree <= 0 || colsampleByTree > 1 {
+		panic("Subsample and ColsampleByTree must be in (0, 1]")
+	}
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
 
 	return &Boosted{
-		X:            x,
-		Y:            y,
-		Estimators:   estimators,
-		Factory:      estimatorFactory,
-		LearningRate: learningRate,
+		X:               x,
+		Y:               y,
+		Factory:         estimatorFactory,
+		NEstimators:     nEstimators,
+		LearningRate:    learningRate,
+		Loss:            SquaredLoss{},
+		Subsample:       subsample,
+		ColsampleByTree: colsampleByTree,
+		RandSeed:        randSeed,
+		rng:             rand.New(rand.NewSource(*randSeed)),
 	}
 }
 
 func NewDefaultBoosted(estimatorFactory func(x [][]float64, y []float64) Estimator, nEstimators int, x [][]float64, y []float64) Estimator {
 	return NewBoosted(estimatorFactory, nEstimators, x, y, 0.1).(*Boosted)
 }
+
+// splitValidation returns the training rows and the validation set. Rows are only held out when there is no explicit
+// validation set and ValidationFraction is positive.
+func (b *Boosted) splitValidation() (train []int, validationX [][]float64, validationY []float64) {
+	train = make([]int, len(b.Y))
+	for i := range train {
+		train[i] = i
+	}
+	if b.ValidationX != nil {
+		if len(b.ValidationX) != len(b.ValidationY) {
+			panic("ValidationX and ValidationY must have the same number of rows")
+		}
+		return train, b.ValidationX, b.ValidationY
+	}
+
+	nValidation := int(b.ValidationFraction * float64(len(b.Y)))
+	if nValidation == 0 || nValidation == len(b.Y) {
+		return train, nil, nil
+	}
+	perm := b.rng.Perm(len(b.Y))
+	train = perm[nValidation:]
+	slices.Sort(train)
+	for _, row := range perm[:nValidation] {
+		validationX = append(validationX, b.X[row])
+		validationY = append(validationY, b.Y[row])
+	}
+	return train, validationX, validationY
+}
+
+// sampleStage draws the rows (in-bag and out-of-bag) and features of a stage from the nRows training rows. Nil
+// features means all of them.
+func (b *Boosted) sampleStage(nRows int) (inBag, outOfBag, features []int) {
+	nFeatures := len(b.X[0])
+
+	if b.Subsample < 1 {
+		perm := b.rng.Perm(nRows)
+		nInBag := max(1, int(b.Subsample*float64(nRows)))
+		inBag, outOfBag = perm[:nInBag], perm[nInBag:]
+		slices.Sort(inBag)
+	} else {
+		inBag = make([]int, nRows)
+		for i := range inBag {
+			inBag[i] = i
+		}
+	}
+
+	if b.ColsampleByTree < 1 {
+		features = b.rng.Perm(nFeatures)[:max(1, int(b.ColsampleByTree*float64(nFeatures)))]
+		slices.Sort(features)
+	}
+	return inBag, outOfBag, features
+}
+
+// project keeps the given features of x, or all of x when features is nil.
+func project(x []float64, features []int) []float64 {
+	if features == nil {
+		return x
+	}
+	out := make([]float64, len(features))
+	for j, f := range features {
+		out[j] = x[f]
+	}
+	return out
+}
+
+func gather(values []float64, rows []int) []float64 {
+	out := make([]float64, len(rows))
+	for i, row := range rows {
+		out[i] = values[row]
+	}
+	return out
+}
+
 func (b *Boosted) Fit() {
-	nEstimators := len(b.Estimators)
+	if b.ValidationFraction < 0 || b.ValidationFraction >= 1 {
+		panic("ValidationFraction must be in [0, 1)")
+	}
+	train, validationX, validationY := b.splitValidation()
+	x, y := make([][]float64, len(train)), gather(b.Y, train)
+	for i, row := range train {
+		x[i] = b.X[row]
+	}
 
-	prevSSR := 0.0
-	for i := 0; i < nEstimators; i++ {
-		if i == 0 {
-			b.Estimators[i] = b.Factory(b.X, b.Y)
-		} else {
-			preds := make([]float64, len(b.Y))
-			for j, row := range b.X {
-				preds[j] = b.Estimators[i-1].Predict(row)
-			}
+	b.InitialPrediction = b.Loss.InitialPrediction(y)
+	preds := make([]float64, len(y))
+	for i := range preds {
+		preds[i] = b.InitialPrediction
+	}
+	validationPreds := make([]float64, len(validationY))
+	for i := range validationPreds {
+		validationPreds[i] = b.InitialPrediction
+	}
 
-			resid := make([]float64, len(b.Y))
-			for j := range b.Y {
-				resid[j] = b.Y[j] - preds[j]
-			}
+	b.Estimators = make([]Estimator, 0, b.NEstimators)
+	b.StepSizes = make([]float64, 0, b.NEstimators)
+	b.LeafValues = make([][]float64, 0, b.NEstimators)
+	b.Features = make([][]int, 0, b.NEstimators)
+	b.OOBImprovement = nil
+	b.TrainLoss = make([]float64, 0, b.NEstimators)
+	b.ValidationLoss = nil
+	update := make([]float64, len(y))
+	bestLoss, sinceBest := 0.0, 0
+	for stage := 0; stage < b.NEstimators; stage++ {
+		inBag, outOfBag, features := b.sampleStage(len(y))
+		stageX := make([][]float64, len(inBag))
+		for i, row := range inBag {
+			stageX[i] = project(x[row], features)
+		}
+		stageY, stagePreds := gather(y, inBag), gather(preds, inBag)
 
-			SSR := 0.0
-			for _, r := range resid {
-				SSR += r * r
+		estimator := b.Factory(stageX, b.Loss.NegativeGradient(stageY, stagePreds))
+		estimator.Fit()
+		var step float64
+		var leafValues []float64
+		if tree, ok := estimator.(LeafIndexer); ok {
+			leafValues = b.leafLineSearch(tree, x, y, preds, inBag, features)
+			step = 1.0
+			for i, row := range x {
+				update[i] = leafValues[tree.LeafIndex(project(row, features))]
 			}
-			if math.Abs((SSR-prevSSR)/(prevSSR+1e-6)) < 5e-4 {
-				estimatorArr := make([]Estimator, i)
-				copy(estimatorArr, b.Estimators[:i])
-				b.Estimators = estimatorArr
-				b.Metrics = metrics.Evaluate(b.Y, preds)
-				return
+		} else {
+			for i, row := range x {
+				update[i] = estimator.Predict(project(row, features))
 			}
+			step = b.Loss.LineSearch(stageY, stagePreds, gather(update, inBag))
+		}
 
-			b.Estimators[i] = b.Factory(b.X, resid)
+		var oobBefore float64
+		if len(outOfBag) > 0 {
+			oobBefore = b.Loss.Loss(gather(y, outOfBag), gather(preds, outOfBag))
+		}
+		for i := range preds {
+			preds[i] += b.LearningRate * step * update[i]
 		}
+		if len(outOfBag) > 0 {
+			oobAfter := b.Loss.Loss(gather(y, outOfBag), gather(preds, outOfBag))
+			b.OOBImprovement = append(b.OOBImprovement, oobBefore-oobAfter)
+		}
+
+		b.Estimators = append(b.Estimators, estimator)
+		b.StepSizes = append(b.StepSizes, step)
+		b.LeafValues = append(b.LeafValues, leafValues)
+		b.Features = append(b.Features, features)
+		b.TrainLoss = append(b.TrainLoss, b.Loss.Loss(y, preds))
 
-		b.Estimators[i].Fit()
+		if validationY == nil {
+			continue
+		}
+		for i, row := range validationX {
+			validationPreds[i] += b.LearningRate * b.stagePredict(stage, row)
+		}
+		loss := b.Loss.Loss(validationY, validationPreds)
+		b.ValidationLoss = append(b.ValidationLoss, loss)
+		if b.NIterNoChange <= 0 {
+			continue
+		}
+		if stage == 0 || loss < bestLoss-b.Tol {
+			bestLoss, sinceBest = loss, 0
+		} else if sinceBest++; sinceBest >= b.NIterNoChange {
+			break
+		}
 	}
-	preds := make([]float64, len(b.Y))
-	for i, row := range b.X {
-		preds[i] = b.Predict(row)
+	b.Metrics = metrics.Evaluate(y, preds)
+}
+
+// leafLineSearch returns the constant minimizing Loss over the in-bag rows of every leaf of a fitted stage.
+func (b *Boosted) leafLineSearch(tree LeafIndexer, x [][]float64, y, preds []float64, inBag, features []int) []float64 {
+	leafRows := make([][]int, tree.NLeaves())
+	for _, row := range inBag {
+		leaf := tree.LeafIndex(project(x[row], features))
+		leafRows[leaf] = append(leafRows[leaf], row)
+	}
+
+	values := make([]float64, len(leafRows))
+	for leaf, rows := range leafRows {
+		if len(rows) == 0 {
+			continue
+		}
+		ones := make([]float64, len(rows))
+		for i := range ones {
+			ones[i] = 1.0
+		}
+		values[leaf] = b.Loss.LineSearch(gather(y, rows), gather(preds, rows), ones)
+	}
+	return values
+}
+
+// stagePredict returns the correction of a stage at x, before shrinkage.
+func (b *Boosted) stagePredict(stage int, x []float64) float64 {
+	x = project(x, b.Features[stage])
+	if values := b.LeafValues[stage]; values != nil {
+		return values[b.Estimators[stage].(LeafIndexer).LeafIndex(x)]
 	}
-	b.Metrics = metrics.Evaluate(b.Y, preds)
+	return b.StepSizes[stage] * b.Estimators[stage].Predict(x)
 }
 
 func (b *Boosted) Predict(x []float64) float64 {
-	pred := 0.0
-	for _, est := range b.Estimators {
-		pred += est.Predict(x) * b.LearningRate
+	pred := b.InitialPrediction
+	for stage := range b.Estimators {
+		pred += b.LearningRate * b.stagePredict(stage, x)
 	}
 	return pred
 }
 
+// StagedPredict returns the prediction for x after each stage, so that StagedPredict(x)[len(Estimators)-1] equals
+// Predict(x).
+func (b *Boosted) StagedPredict(x []float64) []float64 {
+	staged := make([]float64, len(b.Estimators))
+	pred := b.InitialPrediction
+	for stage := range b.Estimators {
+		pred += b.LearningRate * b.stagePredict(stage, x)
+		staged[stage] = pred
+	}
+	return staged
+}
+
+// OOBBestNEstimators returns the number of stages after which the cumulative out-of-bag improvement peaks, an
+// estimate of where adding estimators stops helping. It is 0 without row subsampling.
+func (b *Boosted) OOBBestNEstimators() int {
+	best, bestSum, sum := 0, 0.0, 0.0
+	for stage, improvement := range b.OOBImprovement {
+		sum += improvement
+		if sum > bestSum {
+			best, bestSum = stage+1, sum
+		}
+	}
+	return best
+}
+
 func (b *Boosted) GetMetrics() metrics.Metrics {
 	return b.Metrics
 }
@@ -0,0 +1,88 @@
+package Ensemble_test
+
+import (
+	"GoML/DecTree"
+	"GoML/Ensemble"
+	"GoML/OLS"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// shiftedData draws y = 1000 + x + noise, a target far from zero relative to its spread, so that a stage fitted on
+// the targets instead of the pseudo-residuals, or an update scaled against them, shows up as a prediction off scale.
+func shiftedData(nRows int) ([][]float64, []float64) {
+	rng := rand.New(rand.NewSource(1))
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = []float64{10 * rng.Float64()}
+		y[i] = 1000 + x[i][0] + 0.1*rng.NormFloat64()
+	}
+	return x, y
+}
+
+func TestBoostedShiftedTargets(t *testing.T) {
+	x, y := shiftedData(200)
+	seed := int64(1)
+	bases := map[string]func(x [][]float64, y []float64) Ensemble.Estimator{
+		"linear": OLS.NewOLS,
+		"tree": func(x [][]float64, y []float64) Ensemble.Estimator {
+			return DecTree.NewDecTree(x, y, 3, 2, 1, &seed, nil)
+		},
+	}
+	losses := []struct {
+		name string
+		loss Ensemble.Loss
+	}{
+		{"squared", Ensemble.SquaredLoss{}},
+		{"absolute", Ensemble.AbsoluteLoss{}},
+		{"huber", Ensemble.HuberLoss{Alpha: 0.9}},
+		{"median", Ensemble.QuantileLoss{Quantile: 0.5}},
+		{"quantile_0.9", Ensemble.QuantileLoss{Quantile: 0.9}},
+	}
+
+	for baseName, factory := range bases {
+		for _, tt := range losses {
+			loss := tt.loss
+			t.Run(baseName+"/"+tt.name, func(t *testing.T) {
+				boosted := Ensemble.NewBoosted(factory, 200, x, y, 0.1).(*Ensemble.Boosted)
+				boosted.Loss = loss
+				boosted.Fit()
+
+				constant := make([]float64, len(y))
+				for i := range constant {
+					constant[i] = boosted.InitialPrediction
+				}
+				initialLoss := loss.Loss(y, constant)
+
+				worst := 0.0
+				staged := make([][]float64, len(x))
+				for i, row := range x {
+					worst = math.Max(worst, math.Abs(boosted.Predict(row)-(1000+row[0])))
+					staged[i] = boosted.StagedPredict(row)
+					if last := staged[i][len(staged[i])-1]; last != boosted.Predict(row) {
+						t.Fatalf("row %d: last staged prediction %v differs from Predict %v", i, last, boosted.Predict(row))
+					}
+				}
+				// The noise, and so any quantile of it, is within a few tenths of 1000 + x
+				if worst > 1.5 {
+					t.Errorf("largest prediction error %v, want predictions on the scale of y = 1000 + x", worst)
+				}
+
+				stagePreds := make([]float64, len(x))
+				for stage, trainLoss := range boosted.TrainLoss {
+					for i := range x {
+						stagePreds[i] = staged[i][stage]
+					}
+					if stagedLoss := loss.Loss(y, stagePreds); math.Abs(stagedLoss-trainLoss) > 1e-9*math.Max(1, trainLoss) {
+						t.Fatalf("stage %d: loss of StagedPredict %v, TrainLoss %v", stage, stagedLoss, trainLoss)
+					}
+				}
+				if final := boosted.TrainLoss[len(boosted.TrainLoss)-1]; final > 0.05*initialLoss {
+					t.Errorf("final training loss %v, want below 5%% of the initial %v", final, initialLoss)
+				}
+			})
+		}
+	}
+}
@@ -12,4 +12,31 @@ type Sample struct {
 	X          [][]float64
 	Y          []float64
 	OOBIndices map[int]bool
+	Features   []int // Columns of the original X in X, nil for all of them
+}
+
+// Classifier is an Estimator of class labels. Labels are float64 values in Y like any other target;
+// Predict returns the same label as PredictClass so classifiers compose with code written for Estimator.
+type Classifier interface {
+	Estimator
+	// GetClasses returns the sorted labels seen during Fit, which is also the order of PredictProba.
+	GetClasses() []float64
+	PredictProba([]float64) []float64
+	PredictClass([]float64) float64
+	GetClassificationMetrics() metrics.ClassificationMetrics
+}
+
+// IntervalEstimator is an Estimator that also predicts an interval expected to contain the target.
+type IntervalEstimator interface {
+	Estimator
+	PredictInterval([]float64) (lower, upper float64)
+}
+
+// LeafIndexer is an Estimator that partitions the inputs into leaves with a constant prediction each, such as a
+// decision tree. Boosted line searches every leaf of such a stage separately.
+type LeafIndexer interface {
+	Estimator
+	// LeafIndex returns the leaf holding x, in [0, NLeaves()).
+	LeafIndex([]float64) int
+	NLeaves() int
 }
@@ -0,0 +1,272 @@
+package Ensemble
+
+import (
+	"GoML/metrics"
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+)
+
+// Loss is a differentiable regression loss minimized by gradient boosting. Every stage fits the base estimator to
+// the negative gradient of the loss at the current predictions (the pseudo-residuals), and LineSearch scales the
+// fitted stage back to the loss.
+type Loss interface {
+	Name() string
+	// Loss returns the mean loss of the predictions.
+	Loss(y, pred []float64) float64
+	// InitialPrediction is the constant minimizing the loss over y.
+	InitialPrediction(y []float64) float64
+	// NegativeGradient returns the pseudo-residuals -dL/dpred of every row.
+	NegativeGradient(y, pred []float64) []float64
+	// LineSearch returns the multiplier rho minimizing the loss of pred + rho * update.
+	LineSearch(y, pred, update []float64) float64
+}
+
+// SquaredLoss is (y - pred)^2 / 2, whose pseudo-residuals are the plain residuals.
+type SquaredLoss struct{}
+
+// AbsoluteLoss is |y - pred|. Stages fit the sign of the residuals and predictions move towards the median.
+type AbsoluteLoss struct{}
+
+// HuberLoss is quadratic for residuals up to delta and linear beyond it. Delta is the Alpha quantile of the absolute
+// residuals of the current predictions, so only the largest 1 - Alpha share of residuals is treated as outlying.
+type HuberLoss struct {
+	Alpha float64
+}
+
+// QuantileLoss is the pinball loss of the Quantile-th quantile, so the ensemble predicts that conditional quantile.
+type QuantileLoss struct {
+	Quantile float64
+}
+
+// LossByName returns the boosting loss with the given name: "squared_error", "absolute_error", "huber" or
+// "quantile". Alpha is the quantile of the huber and quantile losses.
+func LossByName(name string, alpha float64) (Loss, error) {
+	switch name {
+	case "squared_error":
+		return SquaredLoss{}, nil
+	case "absolute_error":
+		return AbsoluteLoss{}, nil
+	case "huber", "quantile":
+		if alpha <= 0 || alpha >= 1 {
+			return nil, errors.New("alpha must be in (0, 1)")
+		}
+		if name == "huber" {
+			return HuberLoss{Alpha: alpha}, nil
+		}
+		return QuantileLoss{Quantile: alpha}, nil
+	default:
+		return nil, errors.New("unsupported loss")
+	}
+}
+
+func residuals(y, pred []float64) []float64 {
+	resid := make([]float64, len(y))
+	for i := range y {
+		resid[i] = y[i] - pred[i]
+	}
+	return resid
+}
+
+// pinballLineSearch minimizes sum(pinball_q(r_i - rho * h_i)) over rho. The objective is convex and piecewise linear
+// with kinks at r_i / h_i; its slope starts at -sum(q |h_i|) over h_i > 0 and -sum((1 - q) |h_i|) over h_i < 0 and
+// rises by |h_i| at each kink, so the minimizer is the kink where the slope turns non-negative.
+func pinballLineSearch(resid, update []float64, q float64) float64 {
+	type kink struct{ at, weight float64 }
+	kinks := make([]kink, 0, len(resid))
+	slope := 0.0
+	for i, h := range update {
+		if h == 0 {
+			continue
+		}
+		kinks = append(kinks, kink{resid[i] / h, math.Abs(h)})
+		if h > 0 {
+			slope -= q * h
+		} else {
+			slope += (1 - q) * h
+		}
+	}
+	if len(kinks) == 0 {
+		return 0.0
+	}
+	slices.SortFunc(kinks, func(a, b kink) int {
+		return cmp.Compare(a.at, b.at)
+	})
+
+	for _, k := range kinks {
+		slope += k.weight
+		if slope >= 0 {
+			return k.at
+		}
+	}
+	return kinks[len(kinks)-1].at
+}
+
+func (SquaredLoss) Name() string { return "squared_error" }
+
+func (SquaredLoss) Loss(y, pred []float64) float64 {
+	sum := 0.0
+	for _, r := range residuals(y, pred) {
+		sum += r * r / 2
+	}
+	return sum / float64(len(y))
+}
+
+func (SquaredLoss) InitialPrediction(y []float64) float64 {
+	sum := 0.0
+	for _, val := range y {
+		sum += val
+	}
+	return sum / float64(len(y))
+}
+
+func (SquaredLoss) NegativeGradient(y, pred []float64) []float64 {
+	return residuals(y, pred)
+}
+
+func (SquaredLoss) LineSearch(y, pred, update []float64) float64 {
+	num, den := 0.0, 0.0
+	for i, r := range residuals(y, pred) {
+		num += r * update[i]
+		den += update[i] * update[i]
+	}
+	if den == 0 {
+		return 0.0
+	}
+	return num / den
+}
+
+func (AbsoluteLoss) Name() string { return "absolute_error" }
+
+func (AbsoluteLoss) Loss(y, pred []float64) float64 {
+	sum := 0.0
+	for _, r := range residuals(y, pred) {
+		sum += math.Abs(r)
+	}
+	return sum / float64(len(y))
+}
+
+func (AbsoluteLoss) InitialPrediction(y []float64) float64 {
+	return metrics.QuantileUnsorted(y, 0.5)
+}
+
+func (AbsoluteLoss) NegativeGradient(y, pred []float64) []float64 {
+	grad := residuals(y, pred)
+	for i, r := range grad {
+		grad[i] = sign(r)
+	}
+	return grad
+}
+
+func (AbsoluteLoss) LineSearch(y, pred, update []float64) float64 {
+	return pinballLineSearch(residuals(y, pred), update, 0.5)
+}
+
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1.0
+	case v < 0:
+		return -1.0
+	}
+	return 0.0
+}
+
+func (HuberLoss) Name() string { return "huber" }
+
+// delta is the Alpha quantile of the absolute residuals.
+func (l HuberLoss) delta(resid []float64) float64 {
+	abs := make([]float64, len(resid))
+	for i, r := range resid {
+		abs[i] = math.Abs(r)
+	}
+	return metrics.QuantileUnsorted(abs, l.Alpha)
+}
+
+func huber(r, delta float64) float64 {
+	if math.Abs(r) <= delta {
+		return r * r / 2
+	}
+	return delta * (math.Abs(r) - delta/2)
+}
+
+func (l HuberLoss) Loss(y, pred []float64) float64 {
+	resid := residuals(y, pred)
+	delta := l.delta(resid)
+	sum := 0.0
+	for _, r := range resid {
+		sum += huber(r, delta)
+	}
+	return sum / float64(len(y))
+}
+
+// InitialPrediction is the median, as the residuals that define delta are only known relative to a first guess.
+func (HuberLoss) InitialPrediction(y []float64) float64 {
+	return metrics.QuantileUnsorted(y, 0.5)
+}
+
+func (l HuberLoss) NegativeGradient(y, pred []float64) []float64 {
+	grad := residuals(y, pred)
+	delta := l.delta(grad)
+	for i, r := range grad {
+		if math.Abs(r) > delta {
+			grad[i] = delta * sign(r)
+		}
+	}
+	return grad
+}
+
+// LineSearch holds delta at its value for the current predictions and minimizes the Huber loss by iteratively
+// reweighted least squares, starting from the least squares multiplier.
+func (l HuberLoss) LineSearch(y, pred, update []float64) float64 {
+	resid := residuals(y, pred)
+	delta := l.delta(resid)
+	rho := SquaredLoss{}.LineSearch(y, pred, update)
+	for iter := 0; iter < 50; iter++ {
+		num, den := 0.0, 0.0
+		for i, r := range resid {
+			weight := 1.0
+			if e := math.Abs(r - rho*update[i]); e > delta {
+				weight = delta / e
+			}
+			num += weight * r * update[i]
+			den += weight * update[i] * update[i]
+		}
+		if den == 0 {
+			return 0.0
+		}
+		next := num / den
+		if math.Abs(next-rho) <= 1e-10*math.Max(1, math.Abs(rho)) {
+			return next
+		}
+		rho = next
+	}
+	return rho
+}
+
+func (QuantileLoss) Name() string { return "quantile" }
+
+func (l QuantileLoss) Loss(y, pred []float64) float64 {
+	return metrics.PinballLoss(y, pred, l.Quantile)
+}
+
+func (l QuantileLoss) InitialPrediction(y []float64) float64 {
+	return metrics.QuantileUnsorted(y, l.Quantile)
+}
+
+func (l QuantileLoss) NegativeGradient(y, pred []float64) []float64 {
+	grad := residuals(y, pred)
+	for i, r := range grad {
+		if r > 0 {
+			grad[i] = l.Quantile
+		} else {
+			grad[i] = l.Quantile - 1
+		}
+	}
+	return grad
+}
+
+func (l QuantileLoss) LineSearch(y, pred, update []float64) float64 {
+	return pinballLineSearch(residuals(y, pred), update, l.Quantile)
+}
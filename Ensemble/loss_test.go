@@ -0,0 +1,83 @@
+package Ensemble
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func pinball(resid []float64, q float64) float64 {
+	sum := 0.0
+	for _, r := range resid {
+		if r > 0 {
+			sum += q * r
+		} else {
+			sum += (q - 1) * r
+		}
+	}
+	return sum
+}
+
+// The pinball objective is piecewise linear, so its minimum is attained at one of the kinks r_i / h_i.
+func TestPinballLineSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		n := 1 + rng.Intn(30)
+		shift := 1000 * float64(trial%2)
+		resid, update := make([]float64, n), make([]float64, n)
+		for i := range resid {
+			resid[i] = shift + rng.NormFloat64()
+			update[i] = rng.NormFloat64()
+			if rng.Intn(5) == 0 {
+				update[i] = 0
+			}
+		}
+		for _, q := range []float64{0.1, 0.5, 0.9} {
+			objective := func(rho float64) float64 {
+				shifted := make([]float64, n)
+				for i := range shifted {
+					shifted[i] = resid[i] - rho*update[i]
+				}
+				return pinball(shifted, q)
+			}
+			best := objective(0)
+			for i, h := range update {
+				if h != 0 {
+					best = math.Min(best, objective(resid[i]/h))
+				}
+			}
+
+			got := objective(pinballLineSearch(resid, update, q))
+			if got > best+1e-9*math.Max(1, best) {
+				t.Fatalf("trial %d, q %v: line search reached %v, minimum is %v", trial, q, got, best)
+			}
+		}
+	}
+}
+
+// On targets shifted far from zero every loss starts from a constant on their scale and, given the exact residuals as
+// the update, line searches to a multiplier of one.
+func TestLossesShiftedTargets(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	y := make([]float64, 100)
+	for i := range y {
+		y[i] = 1000 + rng.NormFloat64()
+	}
+
+	losses := []Loss{SquaredLoss{}, AbsoluteLoss{}, HuberLoss{Alpha: 0.9}, QuantileLoss{Quantile: 0.1}, QuantileLoss{Quantile: 0.9}}
+	for _, loss := range losses {
+		initial := loss.InitialPrediction(y)
+		if initial < 997 || initial > 1003 {
+			t.Errorf("%v: initial prediction %v, want about 1000", loss, initial)
+		}
+
+		pred := make([]float64, len(y))
+		for i := range pred {
+			pred[i] = initial
+		}
+		update := residuals(y, pred)
+		if rho := loss.LineSearch(y, pred, update); math.Abs(rho-1) > 1e-6 {
+			t.Errorf("%v: line search along the residuals gave %v, want 1", loss, rho)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package Ensemble
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelFor calls fn(i) for every i in [0, n) on a pool of nJobs goroutines. Every call must only write to its own
+// index so results do not depend on scheduling. nJobs of 0 or 1 runs serially and a negative nJobs uses every CPU.
+// A panic in fn is re-raised on the calling goroutine once the pool has stopped.
+func parallelFor(n, nJobs int, fn func(i int)) {
+	if nJobs < 0 {
+		nJobs = runtime.NumCPU()
+	}
+	if nJobs <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	nJobs = min(nJobs, n)
+
+	// Buffered so that workers stopped by a panic never block the sender
+	jobs := make(chan int, n)
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var panicked any
+	wg.Add(nJobs)
+	for w := 0; w < nJobs; w++ {
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { panicked = r })
+				}
+			}()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if panicked != nil {
+		panic(panicked)
+	}
+}
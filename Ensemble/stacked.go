@@ -0,0 +1,133 @@
+package Ensemble
+
+import (
+	"GoML/crossval"
+	"GoML/metrics"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Stacked is a stacking ensemble over different base estimators. Every base factory is cross-validated with
+// crossval.KFold to get out-of-fold predictions for all rows, a meta-estimator is fitted on those predictions, and the
+// base estimators are refitted on every row for prediction. As the meta-estimator only sees predictions on rows the
+// base estimators were not fitted on, it learns how much to trust each of them on new data rather than on their
+// training rows.
+type Stacked struct {
+	X [][]float64
+	Y []float64
+
+	Estimators  []Estimator
+	Factories   []func(x [][]float64, y []float64) Estimator
+	Meta        Estimator
+	MetaFactory func(x [][]float64, y []float64) Estimator
+	NFolds      int
+
+	// Fit results
+	OOFPredictions [][]float64       // Out-of-fold prediction of every base estimator for every row, the meta features
+	BaseOOFMetrics []metrics.Metrics // Out-of-fold metrics of each base estimator
+	OOFMetrics     metrics.Metrics   // Metrics of the meta-estimator on the out-of-fold predictions
+
+	Metrics metrics.Metrics
+
+	// Random State
+	RandSeed *int64
+	rng      *rand.Rand
+}
+
+func NewStacked(estimatorFactories []func(x [][]float64, y []float64) Estimator, metaFactory func(x [][]float64, y []float64) Estimator, x [][]float64, y []float64, nFolds int, randSeed *int64) Estimator {
+	if len(estimatorFactories) == 0 {
+		panic("Stacked needs at least one base estimator factory")
+	}
+	for j, factory := range estimatorFactories {
+		if factory == nil {
+			panic(fmt.Sprintf("Base estimator factory %d is nil", j))
+		}
+	}
+	if metaFactory == nil {
+		panic("Stacked needs a meta-estimator factory")
+	}
+	if nFolds < 2 || nFolds > len(y) {
+		panic("NFolds must be in [2, nRows]")
+	}
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+
+	return &Stacked{
+		X:           x,
+		Y:           y,
+		Factories:   estimatorFactories,
+		MetaFactory: metaFactory,
+		NFolds:      nFolds,
+		RandSeed:    randSeed,
+		rng:         rand.New(rand.NewSource(*randSeed)),
+	}
+}
+
+func (s *Stacked) Fit() {
+	nRows, nBase := len(s.Y), len(s.Factories)
+	s.OOFPredictions = make([][]float64, nRows)
+	for i := range s.OOFPredictions {
+		s.OOFPredictions[i] = make([]float64, nBase)
+	}
+
+	// Every base estimator sees the same folds
+	folds := crossval.KFold(nRows, s.NFolds, s.rng)
+	for _, fold := range folds {
+		foldX := make([][]float64, len(fold.Train))
+		for i, row := range fold.Train {
+			foldX[i] = s.X[row]
+		}
+		foldY := gather(s.Y, fold.Train)
+		for j, factory := range s.Factories {
+			estimator := factory(foldX, foldY)
+			estimator.Fit()
+			for _, row := range fold.Test {
+				s.OOFPredictions[row][j] = estimator.Predict(s.X[row])
+			}
+		}
+	}
+
+	s.BaseOOFMetrics = make([]metrics.Metrics, nBase)
+	column := make([]float64, nRows)
+	for j := range s.Factories {
+		for i, preds := range s.OOFPredictions {
+			column[i] = preds[j]
+		}
+		s.BaseOOFMetrics[j] = metrics.Evaluate(s.Y, column)
+	}
+
+	s.Meta = s.MetaFactory(s.OOFPredictions, s.Y)
+	s.Meta.Fit()
+	metaPreds := make([]float64, nRows)
+	for i, preds := range s.OOFPredictions {
+		metaPreds[i] = s.Meta.Predict(preds)
+	}
+	s.OOFMetrics = metrics.Evaluate(s.Y, metaPreds)
+
+	s.Estimators = make([]Estimator, nBase)
+	for j, factory := range s.Factories {
+		s.Estimators[j] = factory(s.X, s.Y)
+		s.Estimators[j].Fit()
+	}
+
+	preds := make([]float64, nRows)
+	for i, row := range s.X {
+		preds[i] = s.Predict(row)
+	}
+	s.Metrics = metrics.Evaluate(s.Y, preds)
+}
+
+func (s *Stacked) Predict(x []float64) float64 {
+	baseValues := make([]float64, len(s.Estimators))
+	for j, est := range s.Estimators {
+		baseValues[j] = est.Predict(x)
+	}
+	return s.Meta.Predict(baseValues)
+}
+
+func (s *Stacked) GetMetrics() metrics.Metrics {
+	return s.Metrics
+}
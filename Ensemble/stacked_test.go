@@ -0,0 +1,109 @@
+package Ensemble_test
+
+import (
+	"GoML/Ensemble"
+	"math"
+	"testing"
+)
+
+// recorder remembers the rows it was fitted on, identified by their single feature, and predicts +1 for unseen rows
+// and -1 for rows it saw.
+type recorder struct {
+	constant
+	seen map[float64]bool
+}
+
+func newRecorder(x [][]float64, y []float64) Ensemble.Estimator {
+	r := &recorder{seen: make(map[float64]bool)}
+	for _, row := range x {
+		r.seen[row[0]] = true
+	}
+	return r
+}
+
+func (r *recorder) Predict(x []float64) float64 {
+	if r.seen[x[0]] {
+		return -1
+	}
+	return 1
+}
+
+func rowIndexData(nRows int) ([][]float64, []float64) {
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = []float64{float64(i)}
+		y[i] = float64(i % 3)
+	}
+	return x, y
+}
+
+func TestStackedOutOfFold(t *testing.T) {
+	x, y := rowIndexData(23)
+	seed := int64(1)
+	s := Ensemble.NewStacked([]func(x [][]float64, y []float64) Ensemble.Estimator{newRecorder, newRecorder}, constantFactory(0), x, y, 5, &seed).(*Ensemble.Stacked)
+	s.Fit()
+
+	for i, preds := range s.OOFPredictions {
+		for j, pred := range preds {
+			if pred != 1 {
+				t.Errorf("row %d, base estimator %d: out-of-fold prediction from an estimator fitted on the row", i, j)
+			}
+		}
+	}
+	// The refitted base estimators see every row
+	for j, est := range s.Estimators {
+		for _, row := range x {
+			if est.Predict(row) != -1 {
+				t.Errorf("refitted base estimator %d did not see row %v", j, row)
+			}
+		}
+	}
+}
+
+func TestVotingWeights(t *testing.T) {
+	x, y := rowIndexData(5)
+	factories := []func(x [][]float64, y []float64) Ensemble.Estimator{constantFactory(1), constantFactory(2), constantFactory(4)}
+
+	v := Ensemble.NewVoting(factories, x, y, nil)
+	v.Fit()
+	if got, want := v.Predict(x[0]), 7.0/3; math.Abs(got-want) > 1e-12 {
+		t.Errorf("unweighted vote %v, want %v", got, want)
+	}
+	v = Ensemble.NewVoting(factories, x, y, []float64{1, 2, 1})
+	v.Fit()
+	if got, want := v.Predict(x[0]), (1*1+2*2+1*4)/4.0; math.Abs(got-want) > 1e-12 {
+		t.Errorf("weighted vote %v, want %v", got, want)
+	}
+	v = Ensemble.NewVoting(factories, x, y, []float64{0, 0, 3})
+	v.Fit()
+	if got := v.Predict(x[0]); got != 4 {
+		t.Errorf("vote with a single non-zero weight %v, want 4", got)
+	}
+}
+
+func assertPanics(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: no panic", name)
+		}
+	}()
+	fn()
+}
+
+func TestStackedVotingValidation(t *testing.T) {
+	x, y := rowIndexData(10)
+	valid := []func(x [][]float64, y []float64) Ensemble.Estimator{constantFactory(1), constantFactory(2)}
+	withNil := []func(x [][]float64, y []float64) Ensemble.Estimator{constantFactory(1), nil}
+
+	assertPanics(t, "stacked without factories", func() { Ensemble.NewStacked(nil, constantFactory(0), x, y, 5, nil) })
+	assertPanics(t, "stacked with a nil factory", func() { Ensemble.NewStacked(withNil, constantFactory(0), x, y, 5, nil) })
+	assertPanics(t, "stacked with a nil meta factory", func() { Ensemble.NewStacked(valid, nil, x, y, 5, nil) })
+	assertPanics(t, "stacked with one fold", func() { Ensemble.NewStacked(valid, constantFactory(0), x, y, 1, nil) })
+	assertPanics(t, "voting without factories", func() { Ensemble.NewVoting(nil, x, y, nil) })
+	assertPanics(t, "voting with a nil factory", func() { Ensemble.NewVoting(withNil, x, y, nil) })
+	assertPanics(t, "voting with too few weights", func() { Ensemble.NewVoting(valid, x, y, []float64{1}) })
+	assertPanics(t, "voting with a negative weight", func() { Ensemble.NewVoting(valid, x, y, []float64{1, -1}) })
+	assertPanics(t, "voting with zero weights", func() { Ensemble.NewVoting(valid, x, y, []float64{0, 0}) })
+}
@@ -0,0 +1,84 @@
+package Ensemble
+
+import (
+	"GoML/metrics"
+	"math"
+	"slices"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// Uncertainty is the spread of the estimator predictions of a regression ensemble at one input.
+type Uncertainty struct {
+	Mean  float64 `json:"mean"`
+	Std   float64 `json:"std"`
+	Lower float64 `json:"lower"` // alpha / 2 percentile of the estimator predictions
+	Upper float64 `json:"upper"` // 1 - alpha / 2 percentile of the estimator predictions
+}
+
+func checkAlpha(alpha float64) {
+	if alpha <= 0 || alpha >= 1 {
+		panic("alpha must be in (0, 1)")
+	}
+}
+
+// PredictWithUncertainty returns the mean of the estimator predictions at x, their standard deviation and their
+// central 1 - alpha percentile interval. The spread only reflects how much the estimators disagree, not the noise of
+// the target, so the interval is narrower than a prediction interval; JackknifePlusInterval gives one.
+func (b *Bagged) PredictWithUncertainty(x []float64, alpha float64) Uncertainty {
+	checkAlpha(alpha)
+	preds := b.estimatorPredictions(x, b.NJobs)
+	mean, std := stat.PopMeanStdDev(preds, nil)
+	slices.Sort(preds)
+	return Uncertainty{
+		Mean:  mean,
+		Std:   std,
+		Lower: metrics.Quantile(preds, alpha/2),
+		Upper: metrics.Quantile(preds, 1-alpha/2),
+	}
+}
+
+// JackknifePlusInterval returns the jackknife+-after-bootstrap prediction interval at level 1 - alpha (Kim, Xu and
+// Barber, 2020). Every training row with an out-of-bag prediction contributes its leave-one-out prediction at x, the
+// average of the estimators whose bag left the row out, shifted down and up by its out-of-bag residual. The bounds
+// are order statistics of those values, and infinite when too few rows have out-of-bag predictions for the level.
+func (b *Bagged) JackknifePlusInterval(x []float64, alpha float64) (lower, upper float64) {
+	checkAlpha(alpha)
+	if b.oobPredictions == nil {
+		panic("JackknifePlusInterval requires a fitted ensemble")
+	}
+	preds := b.estimatorPredictions(x, b.NJobs)
+
+	lows := make([]float64, 0, len(b.Y))
+	highs := make([]float64, 0, len(b.Y))
+	for i, oobPred := range b.oobPredictions {
+		if math.IsNaN(oobPred) {
+			continue
+		}
+		sum, nOOB := 0.0, 0
+		for e, pred := range preds {
+			if b.Bags[e].OOBIndices[i] {
+				sum += pred
+				nOOB++
+			}
+		}
+		looPred := sum / float64(nOOB)
+		residual := math.Abs(b.Y[i] - oobPred)
+		lows = append(lows, looPred-residual)
+		highs = append(highs, looPred+residual)
+	}
+
+	n := float64(len(lows))
+	return metrics.OrderStatistic(lows, int(math.Floor(alpha*(n+1)))), metrics.OrderStatistic(highs, int(math.Ceil((1-alpha)*(n+1))))
+}
+
+// PredictWithUncertainty is not defined for classifiers, whose members disagree on class labels rather than on a
+// continuous value; PredictProba gives the spread of the member votes.
+func (bc *BaggedClassifier) PredictWithUncertainty(x []float64, alpha float64) Uncertainty {
+	panic("PredictWithUncertainty is not supported for classifiers, use PredictProba")
+}
+
+// JackknifePlusInterval is not defined for classifiers, as residuals of class labels have no meaning.
+func (bc *BaggedClassifier) JackknifePlusInterval(x []float64, alpha float64) (lower, upper float64) {
+	panic("JackknifePlusInterval is not supported for classifiers")
+}
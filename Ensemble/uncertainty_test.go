@@ -0,0 +1,86 @@
+package Ensemble_test
+
+import (
+	"GoML/Ensemble"
+	"GoML/OLS"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// linearData draws y = 1 + 2x + standard normal noise.
+func linearData(rng *rand.Rand, nRows int) ([][]float64, []float64) {
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = []float64{10 * rng.Float64()}
+		y[i] = 1 + 2*x[i][0] + rng.NormFloat64()
+	}
+	return x, y
+}
+
+// meanFactory fits the mean of the targets of its bag.
+func meanFactory(x [][]float64, y []float64) Ensemble.Estimator {
+	sum := 0.0
+	for _, target := range y {
+		sum += target
+	}
+	return constant(sum / float64(len(y)))
+}
+
+// Averaged over many draws of the data, the jackknife+ intervals contain about 1 - alpha of new targets. The guarantee
+// is only 1 - 2 alpha, but in practice coverage is close to the nominal level.
+func TestJackknifePlusCoverage(t *testing.T) {
+	const alpha, nTrials, nTrain, nTest = 0.1, 50, 100, 100
+	rng := rand.New(rand.NewSource(1))
+	covered := 0
+	for trial := 0; trial < nTrials; trial++ {
+		x, y := linearData(rng, nTrain)
+		seed := int64(trial)
+		bagged := Ensemble.NewBagged(OLS.NewOLS, 30, x, y, &seed).(*Ensemble.Bagged)
+		bagged.Fit()
+
+		xTest, yTest := linearData(rng, nTest)
+		for i, row := range xTest {
+			lower, upper := bagged.JackknifePlusInterval(row, alpha)
+			if lower <= yTest[i] && yTest[i] <= upper {
+				covered++
+			}
+		}
+	}
+
+	coverage := float64(covered) / (nTrials * nTest)
+	if coverage < 1-alpha-0.02 || coverage > 1-alpha+0.04 {
+		t.Errorf("coverage %v, want about %v", coverage, 1-alpha)
+	}
+}
+
+// The bounds are the floor(alpha (n + 1))-th and ceil((1 - alpha) (n + 1))-th of n values, so at alpha = 0.1 they are
+// infinite below n = 1 / alpha - 1 = 9 rows with out-of-bag predictions and finite from there.
+func TestJackknifePlusTooFewRows(t *testing.T) {
+	const alpha = 0.1
+	for _, tc := range []struct {
+		nRows  int
+		finite bool
+	}{
+		{5, false},
+		{8, false},
+		{9, true},
+		{20, true},
+	} {
+		x, y := linearData(rand.New(rand.NewSource(1)), tc.nRows)
+		seed := int64(1)
+		// With this many bags every row is left out by some of them
+		bagged := Ensemble.NewBagged(meanFactory, 200, x, y, &seed).(*Ensemble.Bagged)
+		bagged.Fit()
+
+		lower, upper := bagged.JackknifePlusInterval(x[0], alpha)
+		if tc.finite {
+			if math.IsInf(lower, 0) || math.IsInf(upper, 0) || lower > upper {
+				t.Errorf("%d rows: interval [%v, %v], want finite", tc.nRows, lower, upper)
+			}
+		} else if !math.IsInf(lower, -1) || !math.IsInf(upper, 1) {
+			t.Errorf("%d rows: interval [%v, %v], want (-Inf, +Inf)", tc.nRows, lower, upper)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package Ensemble
+
+import (
+	"GoML/metrics"
+	"fmt"
+)
+
+// Voting averages the predictions of different base estimators, each fitted on every row. Weights, when given, make
+// it a weighted average; nil weights every estimator equally.
+type Voting struct {
+	X [][]float64
+	Y []float64
+
+	Estimators []Estimator
+	Factories  []func(x [][]float64, y []float64) Estimator
+	Weights    []float64
+
+	Metrics metrics.Metrics
+}
+
+func NewVoting(estimatorFactories []func(x [][]float64, y []float64) Estimator, x [][]float64, y []float64, weights []float64) Estimator {
+	if len(estimatorFactories) == 0 {
+		panic("Voting needs at least one base estimator factory")
+	}
+	for j, factory := range estimatorFactories {
+		if factory == nil {
+			panic(fmt.Sprintf("Base estimator factory %d is nil", j))
+		}
+	}
+	if weights != nil {
+		if len(weights) != len(estimatorFactories) {
+			panic("Weights must have one entry per base estimator factory")
+		}
+		sum := 0.0
+		for _, w := range weights {
+			if w < 0 {
+				panic("Weights cannot be negative")
+			}
+			sum += w
+		}
+		if sum == 0 {
+			panic("Weights cannot all be zero")
+		}
+	}
+
+	return &Voting{
+		X:         x,
+		Y:         y,
+		Factories: estimatorFactories,
+		Weights:   weights,
+	}
+}
+
+func (v *Voting) Fit() {
+	v.Estimators = make([]Estimator, len(v.Factories))
+	for j, factory := range v.Factories {
+		v.Estimators[j] = factory(v.X, v.Y)
+		v.Estimators[j].Fit()
+	}
+
+	preds := make([]float64, len(v.Y))
+	for i, row := range v.X {
+		preds[i] = v.Predict(row)
+	}
+	v.Metrics = metrics.Evaluate(v.Y, preds)
+}
+
+func (v *Voting) Predict(x []float64) float64 {
+	sum, sumWeights := 0.0, 0.0
+	for j, est := range v.Estimators {
+		weight := 1.0
+		if v.Weights != nil {
+			weight = v.Weights[j]
+		}
+		sum += weight * est.Predict(x)
+		sumWeights += weight
+	}
+	return sum / sumWeights
+}
+
+func (v *Voting) GetMetrics() metrics.Metrics {
+	return v.Metrics
+}
@@ -0,0 +1,112 @@
+package Forest
+
+import (
+	"GoML/DecTree"
+	"GoML/Ensemble"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RandomForest is a Bagged ensemble of decision trees that each choose every split among a random subset of
+// MaxFeatures features, which decorrelates the trees beyond what bootstrapping alone does (Breiman, 2001).
+type RandomForest struct {
+	*Ensemble.Bagged
+
+	NEstimators     int `json:"n_estimators"`
+	MaxDepth        int `json:"max_depth"`
+	MinSamplesSplit int `json:"min_samples_split"`
+	MinSamplesLeaf  int `json:"min_samples_leaf"`
+	MaxFeatures     int `json:"max_features"` // Features considered at each split
+
+	// Criterion of every tree, applied in Fit. Nil means MSE.
+	Criterion DecTree.Criterion
+
+	// Fit results
+	FeatureImportances []float64 `json:"feature_importances"` // Mean impurity-based importance over the trees
+}
+
+// ExtraTrees is an ensemble of extremely randomized trees (Geurts et al., 2006): on top of the random feature subsets
+// of a RandomForest, every candidate feature is split at a uniformly random threshold instead of its best one.
+type ExtraTrees struct {
+	*RandomForest
+}
+
+func newForest(X [][]float64, Y []float64, nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf, maxFeatures int, randomSplits bool, randSeed *int64) *RandomForest {
+	if len(X) == 0 || len(Y) == 0 {
+		panic("X and Y cannot be empty")
+	}
+	if len(X) != len(Y) {
+		panic("X and Y must have the same number of rows")
+	}
+	if nEstimators <= 0 {
+		panic("NEstimators must be positive")
+	}
+	if maxFeatures <= 0 || maxFeatures > len(X[0]) {
+		panic("MaxFeatures must be in [1, number of features]")
+	}
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+
+	// Every tree gets its own seed, from a generator independent of the one drawing the bags
+	treeSeeds := rand.New(rand.NewSource(^*randSeed))
+	factory := func(x [][]float64, y []float64) Ensemble.Estimator {
+		seed := treeSeeds.Int63()
+		tree := DecTree.NewDecTree(x, y, maxDepth, minSamplesSplit, minSamplesLeaf, &seed, &maxFeatures).(*DecTree.DecTree)
+		tree.RandomSplits = randomSplits
+		return tree
+	}
+
+	return &RandomForest{
+		Bagged:          Ensemble.NewBagged(factory, nEstimators, X, Y, randSeed).(*Ensemble.Bagged),
+		NEstimators:     nEstimators,
+		MaxDepth:        maxDepth,
+		MinSamplesSplit: minSamplesSplit,
+		MinSamplesLeaf:  minSamplesLeaf,
+		MaxFeatures:     maxFeatures,
+	}
+}
+
+// NewRandomForest creates a random forest regressor. A maxFeatures of 0 considers a third of the features at each
+// split, the usual choice for regression.
+func NewRandomForest(X [][]float64, Y []float64, nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf, maxFeatures int, randSeed *int64) Ensemble.Estimator {
+	if maxFeatures == 0 && len(X) > 0 {
+		maxFeatures = max(1, len(X[0])/3)
+	}
+	return newForest(X, Y, nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf, maxFeatures, false, randSeed)
+}
+
+func NewDefaultRandomForest(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewRandomForest(X, Y, 100, 10, 2, 1, 0, nil)
+}
+
+// NewExtraTrees creates an extra-trees regressor. A maxFeatures of 0 considers the square root of the number of
+// features at each split. Unlike Geurts et al. and scikit-learn, whose extra trees see every row by default, the
+// trees are fitted on bootstrap samples like a RandomForest: the random thresholds already decorrelate them, but
+// bootstrapping keeps the out-of-bag metrics and the jackknife+ intervals, which need rows each tree left out.
+func NewExtraTrees(X [][]float64, Y []float64, nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf, maxFeatures int, randSeed *int64) Ensemble.Estimator {
+	if maxFeatures == 0 && len(X) > 0 {
+		maxFeatures = max(1, int(math.Round(math.Sqrt(float64(len(X[0]))))))
+	}
+	return &ExtraTrees{newForest(X, Y, nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf, maxFeatures, true, randSeed)}
+}
+
+func NewDefaultExtraTrees(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewExtraTrees(X, Y, 100, 10, 2, 1, 0, nil)
+}
+
+func (rf *RandomForest) Fit() {
+	for _, estimator := range rf.Estimators {
+		estimator.(*DecTree.DecTree).Criterion = rf.Criterion
+	}
+	rf.Bagged.Fit()
+
+	rf.FeatureImportances = make([]float64, len(rf.X[0]))
+	for _, estimator := range rf.Estimators {
+		for f, importance := range estimator.(*DecTree.DecTree).ImpurityImportances() {
+			rf.FeatureImportances[f] += importance / float64(len(rf.Estimators))
+		}
+	}
+}
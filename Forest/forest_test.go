@@ -0,0 +1,126 @@
+package Forest
+
+import (
+	"GoML/DecTree"
+	"GoML/Ensemble"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// friedman draws nFeatures uniform features, at least three, of which only the first three enter the target.
+func friedman(nRows, nFeatures int) ([][]float64, []float64) {
+	rng := rand.New(rand.NewSource(1))
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = make([]float64, nFeatures)
+		for j := range x[i] {
+			x[i][j] = rng.Float64()
+		}
+		y[i] = 10*math.Sin(math.Pi*x[i][0]*x[i][1]) + 5*x[i][2] + rng.NormFloat64()
+	}
+	return x, y
+}
+
+// uniform draws nFeatures uniform features and the sum of the features as the target.
+func uniform(nRows, nFeatures int) ([][]float64, []float64) {
+	rng := rand.New(rand.NewSource(1))
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = make([]float64, nFeatures)
+		for j := range x[i] {
+			x[i][j] = rng.Float64()
+			y[i] += x[i][j]
+		}
+	}
+	return x, y
+}
+
+type newForestFunc func(X [][]float64, Y []float64, nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf, maxFeatures int, randSeed *int64) Ensemble.Estimator
+
+func forestOf(estimator Ensemble.Estimator) *RandomForest {
+	if et, ok := estimator.(*ExtraTrees); ok {
+		return et.RandomForest
+	}
+	return estimator.(*RandomForest)
+}
+
+func TestFeatureImportances(t *testing.T) {
+	x, y := friedman(300, 6)
+	for name, newForest := range map[string]newForestFunc{"randomforest": NewRandomForest, "extratrees": NewExtraTrees} {
+		t.Run(name, func(t *testing.T) {
+			seed := int64(1)
+			forest := forestOf(newForest(x, y, 20, 6, 2, 1, 0, &seed))
+			forest.Fit()
+
+			total := 0.0
+			for _, importance := range forest.FeatureImportances {
+				total += importance
+			}
+			if math.Abs(total-1) > 1e-12 {
+				t.Errorf("feature importances %v sum to %v", forest.FeatureImportances, total)
+			}
+			// The noise features should matter less than any of the three features of the target
+			for f := 3; f < len(x[0]); f++ {
+				for g := 0; g < 3; g++ {
+					if forest.FeatureImportances[f] >= forest.FeatureImportances[g] {
+						t.Errorf("noise feature %d is as important as feature %d: %v", f, g, forest.FeatureImportances)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRandomSplits(t *testing.T) {
+	x, y := friedman(50, 3)
+	seed := int64(1)
+	for name, tc := range map[string]struct {
+		newForest    newForestFunc
+		randomSplits bool
+	}{
+		"randomforest": {NewRandomForest, false},
+		"extratrees":   {NewExtraTrees, true},
+	} {
+		forest := forestOf(tc.newForest(x, y, 5, 3, 2, 1, 0, &seed))
+		for e, estimator := range forest.Estimators {
+			if got := estimator.(*DecTree.DecTree).RandomSplits; got != tc.randomSplits {
+				t.Errorf("%s: tree %d has RandomSplits %v, want %v", name, e, got, tc.randomSplits)
+			}
+		}
+	}
+}
+
+// Regression forests consider a third of the features at each split by default and extra trees their square root,
+// both at least one. An explicit maxFeatures is kept.
+func TestDefaultMaxFeatures(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		newForest    newForestFunc
+		nFeatures    int
+		maxFeatures  int
+		wantFeatures int
+	}{
+		{"randomforest", NewRandomForest, 2, 0, 1},
+		{"randomforest", NewRandomForest, 13, 0, 4},
+		{"randomforest", NewRandomForest, 13, 7, 7},
+		{"extratrees", NewExtraTrees, 2, 0, 1},
+		{"extratrees", NewExtraTrees, 10, 0, 3},
+		{"extratrees", NewExtraTrees, 13, 0, 4},
+		{"extratrees", NewExtraTrees, 13, 13, 13},
+	} {
+		x, y := uniform(20, tc.nFeatures)
+		seed := int64(1)
+		forest := forestOf(tc.newForest(x, y, 3, 3, 2, 1, tc.maxFeatures, &seed))
+		if forest.MaxFeatures != tc.wantFeatures {
+			t.Errorf("%s with %d features and maxFeatures %d: MaxFeatures %d, want %d", tc.name, tc.nFeatures, tc.maxFeatures, forest.MaxFeatures, tc.wantFeatures)
+		}
+		for e, estimator := range forest.Estimators {
+			if got := *estimator.(*DecTree.DecTree).MaxFeatures; got != tc.wantFeatures {
+				t.Errorf("%s: tree %d considers %d features, want %d", tc.name, e, got, tc.wantFeatures)
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package GLM
+
+import (
+	"fmt"
+	"math"
+)
+
+// Link maps the mean of the target to the linear predictor eta = X * beta.
+type Link interface {
+	Name() string
+	Link(mu float64) float64
+	Inverse(eta float64) float64
+	// Derivative returns d eta / d mu at mu.
+	Derivative(mu float64) float64
+}
+
+type IdentityLink struct{}
+
+func (IdentityLink) Name() string                  { return "identity" }
+func (IdentityLink) Link(mu float64) float64       { return mu }
+func (IdentityLink) Inverse(eta float64) float64   { return eta }
+func (IdentityLink) Derivative(mu float64) float64 { return 1.0 }
+
+type LogLink struct{}
+
+func (LogLink) Name() string                  { return "log" }
+func (LogLink) Link(mu float64) float64       { return math.Log(mu) }
+func (LogLink) Inverse(eta float64) float64   { return math.Exp(eta) }
+func (LogLink) Derivative(mu float64) float64 { return 1.0 / mu }
+
+// Family is an exponential dispersion distribution of the target, described by its variance function and deviance.
+type Family interface {
+	Name() string
+	DefaultLink() Link
+	// ValidTarget reports whether y is in the support of the distribution.
+	ValidTarget(y float64) bool
+	// Variance returns the variance function V(mu); Var(y) = scale * V(mu).
+	Variance(mu float64) float64
+	// UnitDeviance returns the deviance contribution of a single row.
+	UnitDeviance(y, mu float64) float64
+	// LogLikelihood returns the log-likelihood of a single row for the given dispersion (scale).
+	LogLikelihood(y, mu, scale float64) float64
+	// EstimatesScale reports whether the dispersion is estimated from the data (false when it is fixed to 1).
+	EstimatesScale() bool
+}
+
+// Gaussian is the normal distribution, V(mu) = 1. With the identity link the GLM reduces to OLS.
+type Gaussian struct{}
+
+func (Gaussian) Name() string                { return "gaussian" }
+func (Gaussian) DefaultLink() Link           { return IdentityLink{} }
+func (Gaussian) ValidTarget(y float64) bool  { return true }
+func (Gaussian) Variance(mu float64) float64 { return 1.0 }
+func (Gaussian) EstimatesScale() bool        { return true }
+func (Gaussian) UnitDeviance(y, mu float64) float64 {
+	return (y - mu) * (y - mu)
+}
+func (Gaussian) LogLikelihood(y, mu, scale float64) float64 {
+	return -0.5 * (math.Log(2*math.Pi*scale) + (y-mu)*(y-mu)/scale)
+}
+
+// Poisson is the distribution of counts, V(mu) = mu, with the dispersion fixed to 1.
+type Poisson struct{}
+
+func (Poisson) Name() string                { return "poisson" }
+func (Poisson) DefaultLink() Link           { return LogLink{} }
+func (Poisson) ValidTarget(y float64) bool  { return y >= 0 }
+func (Poisson) Variance(mu float64) float64 { return mu }
+func (Poisson) EstimatesScale() bool        { return false }
+func (Poisson) UnitDeviance(y, mu float64) float64 {
+	return 2 * (xlogy(y, y/mu) - (y - mu))
+}
+func (Poisson) LogLikelihood(y, mu, scale float64) float64 {
+	lgammaY, _ := math.Lgamma(y + 1)
+	return xlogy(y, mu) - mu - lgammaY
+}
+
+// Gamma is a distribution of positive, right skewed targets such as claim sizes, V(mu) = mu^2.
+type Gamma struct{}
+
+func (Gamma) Name() string                { return "gamma" }
+func (Gamma) DefaultLink() Link           { return LogLink{} }
+func (Gamma) ValidTarget(y float64) bool  { return y > 0 }
+func (Gamma) Variance(mu float64) float64 { return mu * mu }
+func (Gamma) EstimatesScale() bool        { return true }
+func (Gamma) UnitDeviance(y, mu float64) float64 {
+	return 2 * (-math.Log(y/mu) + (y-mu)/mu)
+}
+func (Gamma) LogLikelihood(y, mu, scale float64) float64 {
+	shape := 1 / scale
+	lgammaShape, _ := math.Lgamma(shape)
+	return shape*math.Log(shape*y/mu) - shape*y/mu - lgammaShape - math.Log(y)
+}
+
+// Tweedie is the family with V(mu) = mu^Power. Power 0, 1 and 2 recover the Gaussian, Poisson and Gamma variance;
+// 1 < Power < 2 is the compound Poisson-Gamma distribution, with a point mass at zero and a continuous positive part.
+type Tweedie struct {
+	Power float64 `json:"power"`
+}
+
+func (t Tweedie) Name() string      { return fmt.Sprintf("tweedie(power=%g)", t.Power) }
+func (t Tweedie) DefaultLink() Link { return LogLink{} }
+func (t Tweedie) ValidTarget(y float64) bool {
+	switch {
+	case t.Power <= 0:
+		return true
+	case t.Power < 2:
+		return y >= 0
+	default:
+		return y > 0
+	}
+}
+func (t Tweedie) Variance(mu float64) float64 { return math.Pow(mu, t.Power) }
+func (t Tweedie) EstimatesScale() bool        { return true }
+
+func (t Tweedie) UnitDeviance(y, mu float64) float64 {
+	p := t.Power
+	switch p {
+	case 0:
+		return Gaussian{}.UnitDeviance(y, mu)
+	case 1:
+		return Poisson{}.UnitDeviance(y, mu)
+	case 2:
+		return Gamma{}.UnitDeviance(y, mu)
+	}
+	first := 0.0
+	if y > 0 {
+		first = math.Pow(y, 2-p) / ((1 - p) * (2 - p))
+	}
+	return 2 * (first - y*math.Pow(mu, 1-p)/(1-p) + math.Pow(mu, 2-p)/(2-p))
+}
+
+// LogLikelihood evaluates the compound Poisson-Gamma density with the series of Dunn and Smyth (2005)
+// for 1 < Power < 2. Other powers without a closed form density return NaN.
+func (t Tweedie) LogLikelihood(y, mu, scale float64) float64 {
+	p := t.Power
+	switch p {
+	case 0:
+		return Gaussian{}.LogLikelihood(y, mu, scale)
+	case 1:
+		return Poisson{}.LogLikelihood(y, mu, scale)
+	case 2:
+		return Gamma{}.LogLikelihood(y, mu, scale)
+	}
+	if p < 1 || p > 2 {
+		return math.NaN()
+	}
+
+	theta := math.Pow(mu, 1-p) / (1 - p)
+	kappa := math.Pow(mu, 2-p) / (2 - p)
+	if y == 0 {
+		return -kappa / scale
+	}
+	return -math.Log(y) + tweedieLogW(y, scale, p) + (y*theta-kappa)/scale
+}
+
+// tweedieLogW sums the series W = sum_j z^j / (j! * Gamma(-j * alpha)) in log space, starting from its largest
+// term and walking outwards until terms are negligible.
+func tweedieLogW(y, scale, p float64) float64 {
+	alpha := (2 - p) / (1 - p)
+	logZ := -alpha*math.Log(y) + alpha*math.Log(p-1) - (1-alpha)*math.Log(scale) - math.Log(2-p)
+	logTerm := func(j float64) float64 {
+		lgammaJ, _ := math.Lgamma(j + 1)
+		lgammaAlpha, _ := math.Lgamma(-j * alpha)
+		return j*logZ - lgammaJ - lgammaAlpha
+	}
+
+	jMax := math.Max(1, math.Round(math.Pow(y, 2-p)/(scale*(2-p))))
+	logMax := logTerm(jMax)
+	cutoff := logMax - 37 // Terms below e^-37 of the largest no longer change a float64 sum
+
+	sum := 1.0
+	for j := jMax + 1; j < jMax+10000; j++ {
+		term := logTerm(j)
+		if term < cutoff {
+			break
+		}
+		sum += math.Exp(term - logMax)
+	}
+	for j := jMax - 1; j >= 1; j-- {
+		term := logTerm(j)
+		if term < cutoff {
+			break
+		}
+		sum += math.Exp(term - logMax)
+	}
+	return logMax + math.Log(sum)
+}
+
+// xlogy returns x * log(y), defined as 0 when x is 0.
+func xlogy(x, y float64) float64 {
+	if x == 0 {
+		return 0
+	}
+	return x * math.Log(y)
+}
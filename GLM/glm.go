@@ -0,0 +1,236 @@
+package GLM
+
+import (
+	"GoML/Ensemble"
+	"GoML/OLS"
+	"GoML/metrics"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// GLM is a generalized linear model with an intercept: g(E[y]) = intercept + X * coefs for a link g, with the
+// variance of y a function of its mean given by Family. It is fitted by iteratively reweighted least squares
+// (Fisher scoring), which for the Gaussian family with the identity link is a single OLS fit.
+type GLM struct {
+	X         [][]float64 `json:"X,omitempty"`
+	Y         []float64   `json:"y,omitempty"`
+	Coefs     []float64   `json:"coefs,omitempty"`
+	Intercept float64     `json:"intercept,omitempty"`
+	Family    Family      `json:"-"`
+	Link      Link        `json:"-"`
+	MaxIter   int         `json:"max_iter"`
+	Tol       float64     `json:"tol"`
+
+	// Fit results
+	Deviance      float64 `json:"deviance"`
+	NullDeviance  float64 `json:"null_deviance"` // Deviance of the intercept-only model
+	D2            float64 `json:"d2"`            // Fraction of deviance explained, 1 - Deviance / NullDeviance
+	Scale         float64 `json:"scale"`         // Dispersion, Pearson chi2 / df_resid (fixed to 1 for Poisson)
+	LogLikelihood float64 `json:"log_likelihood"`
+	AIC           float64 `json:"aic"` // NaN when the family has no closed form likelihood
+	NIter         int     `json:"n_iter"`
+	Converged     bool    `json:"converged"`
+
+	Metrics metrics.Metrics
+}
+
+// NewGLM creates a GLM for the given family. A nil link uses the family's default link (identity for Gaussian,
+// log otherwise).
+func NewGLM(X [][]float64, Y []float64, family Family, link Link, maxIter int, tol float64) Ensemble.Estimator {
+	if len(X) == 0 || len(Y) == 0 {
+		panic("X and Y cannot be empty")
+	}
+	if len(X) != len(Y) {
+		panic("X and Y must have the same number of rows")
+	}
+	if family == nil {
+		panic("Family cannot be nil")
+	}
+	if link == nil {
+		link = family.DefaultLink()
+	}
+	if maxIter <= 0 {
+		panic("MaxIter must be positive")
+	}
+
+	preAllocX := make([][]float64, len(X))
+	for i := range X {
+		if len(X[i]) == 0 {
+			panic("X cannot have empty rows")
+		}
+		preAllocX[i] = make([]float64, len(X[i]))
+		copy(preAllocX[i], X[i])
+	}
+
+	preAllocY := make([]float64, len(Y))
+	for i, val := range Y {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			panic(fmt.Sprintf("Y contains NaN or Inf at index %d", i))
+		}
+		if !family.ValidTarget(val) {
+			panic(fmt.Sprintf("Y[%d] = %v is outside the support of the %s family", i, val, family.Name()))
+		}
+		preAllocY[i] = val
+	}
+	// Exact zeros are kept, they are in the support of Poisson and Tweedie. Only an all-zero target under the log
+	// link has no finite MLE.
+	if _, isLog := link.(LogLink); isLog && slices.Max(preAllocY) == 0 {
+		panic("Y cannot be all zero with the log link")
+	}
+
+	return &GLM{
+		X:       preAllocX,
+		Y:       preAllocY,
+		Coefs:   make([]float64, len(X[0])),
+		Family:  family,
+		Link:    link,
+		MaxIter: maxIter,
+		Tol:     tol,
+	}
+}
+
+func NewDefaultGLM(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewGLM(X, Y, Gaussian{}, nil, 100, 1e-8)
+}
+
+// NewPoissonGLM creates a log-link Poisson regressor for counts and rates.
+func NewPoissonGLM(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewGLM(X, Y, Poisson{}, nil, 100, 1e-8)
+}
+
+// NewGammaGLM creates a log-link Gamma regressor for positive, right skewed targets.
+func NewGammaGLM(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewGLM(X, Y, Gamma{}, nil, 100, 1e-8)
+}
+
+// NewTweedieGLM creates a log-link Tweedie regressor. A power in (1, 2) suits non-negative targets with exact zeros,
+// such as insurance claim amounts.
+func NewTweedieGLM(X [][]float64, Y []float64, power float64) Ensemble.Estimator {
+	if power > 0 && power < 1 {
+		panic("Tweedie power must not lie in (0, 1), where no distribution exists")
+	}
+	return NewGLM(X, Y, Tweedie{Power: power}, nil, 100, 1e-8)
+}
+
+func (g *GLM) linearPredictor(x []float64, intercept float64, coefs []float64) float64 {
+	eta := intercept
+	for j, coef := range coefs {
+		eta += coef * x[j]
+	}
+	return eta
+}
+
+func (g *GLM) deviance(mu []float64) float64 {
+	dev := 0.0
+	for i, y := range g.Y {
+		dev += g.Family.UnitDeviance(y, mu[i])
+	}
+	return dev
+}
+
+func (g *GLM) means(intercept float64, coefs []float64) []float64 {
+	mu := make([]float64, len(g.Y))
+	for i, row := range g.X {
+		mu[i] = g.Link.Inverse(g.linearPredictor(row, intercept, coefs))
+	}
+	return mu
+}
+
+func (g *GLM) Fit() {
+	nRows := len(g.Y)
+
+	yMean := 0.0
+	for _, y := range g.Y {
+		yMean += y
+	}
+	yMean /= float64(nRows)
+
+	// Start halfway between each target and the mean, which keeps mu inside the support of the log link
+	mu := make([]float64, nRows)
+	for i, y := range g.Y {
+		mu[i] = (y + yMean) / 2
+	}
+	dev := g.deviance(mu)
+
+	z := make([]float64, nRows)
+	weights := make([]float64, nRows)
+	rank := len(g.Coefs) + 1
+	g.Converged = false
+	for iter := 1; iter <= g.MaxIter; iter++ {
+		g.NIter = iter
+		for i, y := range g.Y {
+			gPrime := g.Link.Derivative(mu[i])
+			z[i] = g.Link.Link(mu[i]) + (y-mu[i])*gPrime
+			weights[i] = math.Max(1/(g.Family.Variance(mu[i])*gPrime*gPrime), 1e-12)
+		}
+
+		model := OLS.NewWLS(g.X, z, weights).(*OLS.OLS)
+		model.Fit()
+		intercept, coefs := model.Intercept, slices.Clone(model.Coefs)
+		rank = model.Inference.DFModel + 1 // Numerical rank of the design, intercept included
+
+		// Halve the step towards the previous solution while it leaves the domain of the family
+		newMu := g.means(intercept, coefs)
+		newDev := g.deviance(newMu)
+		for halvings := 0; (math.IsNaN(newDev) || math.IsInf(newDev, 0)) && halvings < 30 && iter > 1; halvings++ {
+			intercept = (intercept + g.Intercept) / 2
+			for j := range coefs {
+				coefs[j] = (coefs[j] + g.Coefs[j]) / 2
+			}
+			newMu = g.means(intercept, coefs)
+			newDev = g.deviance(newMu)
+		}
+
+		g.Intercept, g.Coefs = intercept, coefs
+		mu = newMu
+		change := math.Abs(newDev-dev) / (math.Abs(newDev) + 0.1)
+		dev = newDev
+		if change < g.Tol {
+			g.Converged = true
+			break
+		}
+	}
+
+	g.Deviance = dev
+	nullMu := make([]float64, nRows)
+	for i := range nullMu {
+		nullMu[i] = yMean // The intercept-only MLE is the mean for every family and link
+	}
+	g.NullDeviance = g.deviance(nullMu)
+	g.D2 = 1 - g.Deviance/g.NullDeviance
+
+	nParams := rank
+	g.Scale = 1.0
+	if g.Family.EstimatesScale() {
+		if _, isGaussian := g.Family.(Gaussian); isGaussian {
+			// Maximum likelihood estimate, so the AIC matches that of OLS
+			g.Scale = g.Deviance / float64(nRows)
+		} else if nRows > nParams {
+			pearson := 0.0
+			for i, y := range g.Y {
+				pearson += (y - mu[i]) * (y - mu[i]) / g.Family.Variance(mu[i])
+			}
+			g.Scale = pearson / float64(nRows-nParams)
+		}
+	}
+
+	g.LogLikelihood = 0.0
+	for i, y := range g.Y {
+		g.LogLikelihood += g.Family.LogLikelihood(y, mu[i], g.Scale)
+	}
+	g.AIC = -2*g.LogLikelihood + 2*float64(nParams)
+
+	g.Metrics = metrics.Evaluate(g.Y, mu)
+}
+
+func (g *GLM) Predict(x []float64) float64 {
+	if len(x) != len(g.Coefs) {
+		panic("Input feature length does not match number of coefficients")
+	}
+	return g.Link.Inverse(g.linearPredictor(x, g.Intercept, g.Coefs))
+}
+
+func (g *GLM) GetMetrics() metrics.Metrics {
+	return g.Metrics
+}
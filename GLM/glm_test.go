@@ -0,0 +1,73 @@
+package GLM
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// Targets with exact zeros, which Poisson and Tweedie (1 < power < 2) give a point mass. Reference values come from
+// an independent IRLS fit, with the Tweedie density summed as a Poisson mixture of Gamma distributions.
+func zeroInflatedData() (x [][]float64, counts, amounts []float64) {
+	x = make([][]float64, 20)
+	for i := range x {
+		x[i] = []float64{float64(i) / 10}
+	}
+	counts = []float64{0, 1, 0, 2, 1, 0, 3, 1, 2, 4, 2, 3, 5, 2, 6, 4, 7, 5, 8, 6}
+	amounts = []float64{0, 0.8, 0, 1.9, 1.1, 0, 2.7, 1.3, 0, 3.9, 2.2, 0, 4.8, 2.5, 5.6, 0, 6.9, 5.1, 7.7, 6.4}
+	return
+}
+
+func TestAICWithZeros(t *testing.T) {
+	x, counts, amounts := zeroInflatedData()
+
+	tests := []struct {
+		name                                  string
+		model                                 *GLM
+		y                                     []float64
+		intercept, coef, scale, logLikelihood float64
+	}{
+		{"poisson", NewPoissonGLM(x, counts).(*GLM), counts, -0.3273117547018036, 1.2678987841943803, 1, -32.14035471184274},
+		{"tweedie", NewTweedieGLM(x, amounts, 1.5).(*GLM), amounts, -0.6719117926644067, 1.4077524669119559, 0.8340617253586696, -44.91515302591901},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := tt.model
+			g.Fit()
+			if !slices.Equal(g.Y, tt.y) {
+				t.Fatalf("Y = %v, want the targets unchanged %v", g.Y, tt.y)
+			}
+
+			got := []float64{g.Intercept, g.Coefs[0], g.Scale, g.LogLikelihood, g.AIC}
+			want := []float64{tt.intercept, tt.coef, tt.scale, tt.logLikelihood, -2*tt.logLikelihood + 4}
+			names := []string{"Intercept", "Coef", "Scale", "LogLikelihood", "AIC"}
+			for k := range want {
+				if math.Abs(got[k]-want[k]) > 1e-6*math.Max(1, math.Abs(want[k])) {
+					t.Errorf("%s = %.10g, want %.10g", names[k], got[k], want[k])
+				}
+			}
+		})
+	}
+}
+
+func TestFamilySupport(t *testing.T) {
+	tests := []struct {
+		family Family
+		y      float64
+		valid  bool
+	}{
+		{Gaussian{}, -1, true},
+		{Poisson{}, 0, true},
+		{Poisson{}, -1, false},
+		{Gamma{}, 0, false},
+		{Gamma{}, 0.5, true},
+		{Tweedie{Power: 1.5}, 0, true},
+		{Tweedie{Power: 1.5}, -1, false},
+		{Tweedie{Power: 3}, 0, false},
+	}
+	for _, tt := range tests {
+		if got := tt.family.ValidTarget(tt.y); got != tt.valid {
+			t.Errorf("%s.ValidTarget(%v) = %v, want %v", tt.family.Name(), tt.y, got, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package HistGB
+
+import (
+	"GoML/metrics"
+	"slices"
+	"sort"
+)
+
+// binEdges returns the upper edges of the bins of a feature: a value v falls into the first bin b with
+// v <= edges[b], or into the last bin len(edges) when it exceeds every edge. Features with at most maxBins distinct
+// values get one bin per value, others are cut at maxBins - 1 quantiles.
+func binEdges(values []float64, maxBins int) []float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	distinct := slices.Compact(slices.Clone(sorted))
+
+	if len(distinct) <= maxBins {
+		edges := make([]float64, len(distinct)-1)
+		for i := range edges {
+			edges[i] = (distinct[i] + distinct[i+1]) / 2
+		}
+		return edges
+	}
+
+	edges := make([]float64, 0, maxBins-1)
+	for k := 1; k < maxBins; k++ {
+		edges = append(edges, metrics.Quantile(sorted, float64(k)/float64(maxBins)))
+	}
+	// Heavily repeated values produce equal quantiles, and the largest value needs a bin of its own
+	edges = slices.Compact(edges)
+	if edges[len(edges)-1] >= sorted[len(sorted)-1] {
+		edges = edges[:len(edges)-1]
+	}
+	return edges
+}
+
+func binOf(edges []float64, v float64) uint8 {
+	return uint8(sort.SearchFloat64s(edges, v))
+}
@@ -0,0 +1,214 @@
+package HistGB
+
+import (
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"math/rand"
+	"time"
+)
+
+// HistGradientBoosting is a gradient boosted tree ensemble for squared error in the style of LightGBM. Features are
+// discretized into at most MaxBins quantile bins once, and every tree is grown best-first from per-bin sums of the
+// gradients and hessians of the loss, so finding a split costs O(bins) per feature instead of sorting the rows.
+type HistGradientBoosting struct {
+	X [][]float64 `json:"X,omitempty"`
+	Y []float64   `json:"y,omitempty"`
+
+	LearningRate     float64 `json:"learning_rate"`
+	MaxIter          int     `json:"max_iter"`       // Maximum number of trees
+	MaxLeafNodes     int     `json:"max_leaf_nodes"` // Leaves per tree
+	MinSamplesLeaf   int     `json:"min_samples_leaf"`
+	L2Regularization float64 `json:"l2_regularization"` // L2 penalty on the leaf values
+	MaxBins          int     `json:"max_bins"`          // At most 255
+
+	// Early stopping: a random ValidationFraction of the rows is held out, and boosting stops once the validation
+	// loss has not improved by more than Tol for NIterNoChange iterations. A zero ValidationFraction or
+	// NIterNoChange trains all MaxIter trees on every row.
+	ValidationFraction float64 `json:"validation_fraction"`
+	NIterNoChange      int     `json:"n_iter_no_change"`
+	Tol                float64 `json:"tol"`
+
+	// Fit results
+	Baseline       float64   `json:"baseline"` // Initial prediction, the mean of the training targets
+	NIter          int       `json:"n_iter"`
+	TrainLoss      []float64 `json:"train_loss"`      // Mean squared error on the training rows after each tree
+	ValidationLoss []float64 `json:"validation_loss"` // Mean squared error on the held out rows after each tree
+
+	Metrics metrics.Metrics
+
+	RandSeed *int64 `json:"random_seed"`
+	rng      *rand.Rand
+	trees    []*treeNode
+}
+
+func NewHistGradientBoosting(X [][]float64, Y []float64, learningRate float64, maxIter, maxLeafNodes, minSamplesLeaf int, l2Regularization, validationFraction float64, nIterNoChange int, tol float64, randSeed *int64) Ensemble.Estimator {
+	if len(X) == 0 || len(Y) == 0 {
+		panic("X and Y cannot be empty")
+	}
+	if len(X) != len(Y) {
+		panic("X and Y must have the same number of rows")
+	}
+	if learningRate <= 0 {
+		panic("LearningRate must be positive")
+	}
+	if maxIter <= 0 {
+		panic("MaxIter must be positive")
+	}
+	if maxLeafNodes < 2 {
+		panic("MaxLeafNodes must be at least 2")
+	}
+	if minSamplesLeaf < 1 {
+		panic("MinSamplesLeaf must be at least 1")
+	}
+	if l2Regularization < 0 {
+		panic("L2Regularization cannot be negative")
+	}
+	if validationFraction < 0 || validationFraction >= 1 {
+		panic("ValidationFraction must be in [0, 1)")
+	}
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+
+	preAllocX := make([][]float64, len(X))
+	for i := range X {
+		preAllocX[i] = make([]float64, len(X[i]))
+		copy(preAllocX[i], X[i])
+	}
+
+	preAllocY := make([]float64, len(Y))
+	copy(preAllocY, Y)
+
+	return &HistGradientBoosting{
+		X:                  preAllocX,
+		Y:                  preAllocY,
+		LearningRate:       learningRate,
+		MaxIter:            maxIter,
+		MaxLeafNodes:       maxLeafNodes,
+		MinSamplesLeaf:     minSamplesLeaf,
+		L2Regularization:   l2Regularization,
+		MaxBins:            255,
+		ValidationFraction: validationFraction,
+		NIterNoChange:      nIterNoChange,
+		Tol:                tol,
+		RandSeed:           randSeed,
+		rng:                rand.New(rand.NewSource(*randSeed)),
+	}
+}
+
+func NewDefaultHistGradientBoosting(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewHistGradientBoosting(X, Y, 0.1, 100, 31, 20, 0, 0.1, 10, 1e-7, nil)
+}
+
+// splitRows holds out a random ValidationFraction of the rows when early stopping is enabled.
+func (hgb *HistGradientBoosting) splitRows() (train, validation []int) {
+	perm := hgb.rng.Perm(len(hgb.Y))
+	nValidation := int(hgb.ValidationFraction * float64(len(hgb.Y)))
+	if hgb.NIterNoChange <= 0 || nValidation == 0 || nValidation == len(hgb.Y) {
+		return perm, nil
+	}
+	return perm[nValidation:], perm[:nValidation]
+}
+
+func (hgb *HistGradientBoosting) mse(rows []int, raw []float64) float64 {
+	sum := 0.0
+	for _, row := range rows {
+		sum += (hgb.Y[row] - raw[row]) * (hgb.Y[row] - raw[row])
+	}
+	return sum / float64(len(rows))
+}
+
+func (hgb *HistGradientBoosting) Fit() {
+	if hgb.MaxBins < 2 || hgb.MaxBins > 255 {
+		panic("MaxBins must be in [2, 255]")
+	}
+	nRows, nFeatures := len(hgb.Y), len(hgb.X[0])
+	train, validation := hgb.splitRows()
+
+	// Bin edges come from the training rows only, every row is binned with them
+	grower := &treeGrower{
+		binned:         make([][]uint8, nFeatures),
+		edges:          make([][]float64, nFeatures),
+		grad:           make([]float64, nRows),
+		hess:           make([]float64, nRows),
+		maxLeafNodes:   hgb.MaxLeafNodes,
+		minSamplesLeaf: hgb.MinSamplesLeaf,
+		l2:             hgb.L2Regularization,
+	}
+	values := make([]float64, len(train))
+	for f := 0; f < nFeatures; f++ {
+		for i, row := range train {
+			values[i] = hgb.X[row][f]
+		}
+		grower.edges[f] = binEdges(values, hgb.MaxBins)
+		grower.binned[f] = make([]uint8, nRows)
+		for row := range hgb.X {
+			grower.binned[f][row] = binOf(grower.edges[f], hgb.X[row][f])
+		}
+	}
+
+	hgb.Baseline = 0.0
+	for _, row := range train {
+		hgb.Baseline += hgb.Y[row]
+	}
+	hgb.Baseline /= float64(len(train))
+
+	raw := make([]float64, nRows)
+	for row := range raw {
+		raw[row] = hgb.Baseline
+	}
+
+	hgb.trees = nil
+	hgb.TrainLoss = nil
+	hgb.ValidationLoss = nil
+	bestLoss, sinceBest := 0.0, 0
+	for iter := 0; iter < hgb.MaxIter; iter++ {
+		// Squared error (y - raw)^2 / 2 has gradient raw - y and unit hessian
+		for _, row := range train {
+			grower.grad[row] = raw[row] - hgb.Y[row]
+			grower.hess[row] = 1.0
+		}
+
+		tree, leaves := grower.grow(train, hgb.LearningRate)
+		hgb.trees = append(hgb.trees, tree)
+		for _, leaf := range leaves {
+			for _, row := range leaf.rows {
+				raw[row] += leaf.node.value
+			}
+		}
+		hgb.TrainLoss = append(hgb.TrainLoss, hgb.mse(train, raw))
+
+		if validation == nil {
+			continue
+		}
+		for _, row := range validation {
+			raw[row] += tree.predict(hgb.X[row])
+		}
+		loss := hgb.mse(validation, raw)
+		hgb.ValidationLoss = append(hgb.ValidationLoss, loss)
+		if iter == 0 || loss < bestLoss-hgb.Tol {
+			bestLoss, sinceBest = loss, 0
+		} else if sinceBest++; sinceBest >= hgb.NIterNoChange {
+			break
+		}
+	}
+	hgb.NIter = len(hgb.trees)
+	hgb.Metrics = metrics.Evaluate(hgb.Y, raw)
+}
+
+func (hgb *HistGradientBoosting) Predict(x []float64) float64 {
+	if len(x) != len(hgb.X[0]) {
+		panic("Input feature length does not match the training data")
+	}
+
+	pred := hgb.Baseline
+	for _, tree := range hgb.trees {
+		pred += tree.predict(x)
+	}
+	return pred
+}
+
+func (hgb *HistGradientBoosting) GetMetrics() metrics.Metrics {
+	return hgb.Metrics
+}
@@ -0,0 +1,219 @@
+package HistGB
+
+import (
+	"GoML/metrics"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBinEdges(t *testing.T) {
+	t.Run("few distinct values", func(t *testing.T) {
+		edges := binEdges([]float64{3, 1, 3, 2, 1, 3}, 255)
+		if !slices.Equal(edges, []float64{1.5, 2.5}) {
+			t.Errorf("edges %v, want one bin per value [1.5 2.5]", edges)
+		}
+	})
+
+	rng := rand.New(rand.NewSource(1))
+	ties := make([]float64, 1000)
+	for i := range ties {
+		if i >= 900 {
+			ties[i] = rng.Float64()
+		} else if i%2 == 0 {
+			ties[i] = 5 // Most rows share the largest value
+		}
+	}
+	distinct := make([]float64, 10000)
+	for i := range distinct {
+		distinct[i] = rng.NormFloat64()
+	}
+
+	for name, values := range map[string][]float64{"ties": ties, "distinct": distinct} {
+		t.Run(name, func(t *testing.T) {
+			edges := binEdges(values, 255)
+			if len(edges)+1 > 255 {
+				t.Fatalf("%d bins, want at most 255", len(edges)+1)
+			}
+			if name == "distinct" && len(edges)+1 != 255 {
+				t.Errorf("%d bins for %d distinct values, want 255", len(edges)+1, len(values))
+			}
+			for i := 1; i < len(edges); i++ {
+				if edges[i] <= edges[i-1] {
+					t.Fatalf("edges not strictly increasing at %d: %v, %v", i, edges[i-1], edges[i])
+				}
+			}
+			// Every bin is used, including the last one holding the largest value
+			used := make([]bool, len(edges)+1)
+			for _, v := range values {
+				used[binOf(edges, v)] = true
+			}
+			if i := slices.Index(used, false); i != -1 {
+				t.Errorf("bin %d of %d is empty", i, len(used))
+			}
+		})
+	}
+}
+
+// newTestGrower bins x like Fit and sets random gradients and unit hessians.
+func newTestGrower(x [][]float64, maxLeafNodes, minSamplesLeaf int, rng *rand.Rand) *treeGrower {
+	nRows, nFeatures := len(x), len(x[0])
+	g := &treeGrower{
+		binned:         make([][]uint8, nFeatures),
+		edges:          make([][]float64, nFeatures),
+		grad:           make([]float64, nRows),
+		hess:           make([]float64, nRows),
+		maxLeafNodes:   maxLeafNodes,
+		minSamplesLeaf: minSamplesLeaf,
+	}
+	column := make([]float64, nRows)
+	for f := 0; f < nFeatures; f++ {
+		for row := range x {
+			column[row] = x[row][f]
+		}
+		g.edges[f] = binEdges(column, 255)
+		g.binned[f] = make([]uint8, nRows)
+		for row := range x {
+			g.binned[f][row] = binOf(g.edges[f], x[row][f])
+		}
+	}
+	for row := range g.grad {
+		g.grad[row] = -math.Sin(3*x[row][0]) - x[row][1] + 0.1*rng.NormFloat64()
+		g.hess[row] = 1.0
+	}
+	return g
+}
+
+func randomX(rng *rand.Rand, nRows int) [][]float64 {
+	x := make([][]float64, nRows)
+	for i := range x {
+		x[i] = []float64{rng.Float64(), rng.Float64(), math.Round(4 * rng.Float64())}
+	}
+	return x
+}
+
+func TestSubtract(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	g := newTestGrower(randomX(rng, 500), 31, 1, rng)
+
+	var all, left, right []int
+	for row := range g.grad {
+		all = append(all, row)
+		if rng.Intn(3) == 0 {
+			left = append(left, row)
+		} else {
+			right = append(right, row)
+		}
+	}
+
+	got := subtract(g.histogram(all), g.histogram(left))
+	want := g.histogram(right)
+	for f := range want {
+		for b := range want[f] {
+			if got[f][b].count != want[f][b].count ||
+				math.Abs(got[f][b].grad-want[f][b].grad) > 1e-9 || math.Abs(got[f][b].hess-want[f][b].hess) > 1e-9 {
+				t.Fatalf("feature %d bin %d: subtracted %+v, built %+v", f, b, got[f][b], want[f][b])
+			}
+		}
+	}
+}
+
+func TestGrowBestFirst(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	x := randomX(rng, 1000)
+	rows := make([]int, len(x))
+	for i := range rows {
+		rows[i] = i
+	}
+
+	for _, tt := range []struct{ maxLeafNodes, minSamplesLeaf int }{{2, 1}, {7, 1}, {31, 20}, {31, 150}} {
+		g := newTestGrower(x, tt.maxLeafNodes, tt.minSamplesLeaf, rng)
+		root, leaves := g.grow(rows, 1.0)
+
+		if len(leaves) > tt.maxLeafNodes {
+			t.Errorf("%+v: %d leaves", tt, len(leaves))
+		}
+		var seen []int
+		for _, leaf := range leaves {
+			if len(leaf.rows) < tt.minSamplesLeaf {
+				t.Errorf("%+v: leaf with %d rows", tt, len(leaf.rows))
+			}
+			for _, row := range leaf.rows {
+				if got := root.predict(x[row]); got != leaf.node.value {
+					t.Fatalf("%+v: row %d predicts %v, its leaf holds %v", tt, row, got, leaf.node.value)
+				}
+			}
+			seen = append(seen, leaf.rows...)
+		}
+		slices.Sort(seen)
+		if !slices.Equal(seen, rows) {
+			t.Errorf("%+v: leaves do not partition the rows", tt)
+		}
+		// Without the row limit binding, the signal keeps every split worthwhile
+		if tt.minSamplesLeaf*tt.maxLeafNodes <= len(rows)/2 && len(leaves) != tt.maxLeafNodes {
+			t.Errorf("%+v: %d leaves, want MaxLeafNodes", tt, len(leaves))
+		}
+	}
+}
+
+func TestEarlyStopping(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	x := randomX(rng, 400)
+	y := make([]float64, len(x))
+	for i := range y {
+		y[i] = math.Sin(3*x[i][0]) + x[i][1] + 0.5*rng.NormFloat64() // Noisy enough to overfit within MaxIter
+	}
+	seed := int64(1)
+	const nIterNoChange, tol = 5, 1e-7
+	hgb := NewHistGradientBoosting(x, y, 0.05, 300, 31, 5, 0, 0.25, nIterNoChange, tol, &seed).(*HistGradientBoosting)
+	hgb.Fit()
+
+	if hgb.NIter == hgb.MaxIter {
+		t.Fatalf("fitted all %d trees, want early stopping", hgb.MaxIter)
+	}
+	if len(hgb.ValidationLoss) != hgb.NIter || len(hgb.TrainLoss) != hgb.NIter {
+		t.Fatalf("%d trees with %d validation and %d training losses", hgb.NIter, len(hgb.ValidationLoss), len(hgb.TrainLoss))
+	}
+	best := 0
+	for iter, loss := range hgb.ValidationLoss {
+		if loss < hgb.ValidationLoss[best]-tol {
+			best = iter
+		}
+	}
+	if hgb.NIter != best+1+nIterNoChange {
+		t.Errorf("stopped after %d trees, want %d: best validation loss at tree %d", hgb.NIter, best+1+nIterNoChange, best+1)
+	}
+
+	// Metrics are computed from the predictions accumulated during Fit, on the training and validation rows
+	preds := make([]float64, len(x))
+	for i, row := range x {
+		preds[i] = hgb.Predict(row)
+	}
+	if got, want := metrics.Evaluate(y, preds), hgb.Metrics; math.Abs(got.MSE-want.MSE) > 1e-12 || math.Abs(got.MAE-want.MAE) > 1e-12 {
+		t.Errorf("Predict gives %+v, Fit accumulated %+v", got, want)
+	}
+}
+
+func TestPredictMatchesTrainLoss(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	x := randomX(rng, 300)
+	y := make([]float64, len(x))
+	for i := range y {
+		y[i] = math.Sin(3*x[i][0]) + x[i][2]
+	}
+	seed := int64(1)
+	hgb := NewHistGradientBoosting(x, y, 0.1, 50, 15, 5, 1, 0, 0, 0, &seed).(*HistGradientBoosting)
+	hgb.Fit()
+
+	sum := 0.0
+	for i, row := range x {
+		sum += (y[i] - hgb.Predict(row)) * (y[i] - hgb.Predict(row))
+	}
+	if got, want := sum/float64(len(y)), hgb.TrainLoss[len(hgb.TrainLoss)-1]; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Predict MSE %v, final training loss %v", got, want)
+	}
+	if hgb.NIter != hgb.MaxIter || hgb.ValidationLoss != nil {
+		t.Errorf("without a validation fraction, fitted %d of %d trees with validation loss %v", hgb.NIter, hgb.MaxIter, hgb.ValidationLoss)
+	}
+}
@@ -0,0 +1,192 @@
+package HistGB
+
+import "math"
+
+// treeNode is a node of a fitted tree. Thresholds are raw feature values (the upper edge of the last bin sent left),
+// so prediction does not need to bin its input.
+type treeNode struct {
+	feature     int
+	threshold   float64
+	left, right *treeNode
+	value       float64
+	isLeaf      bool
+}
+
+func (node *treeNode) predict(x []float64) float64 {
+	for !node.isLeaf {
+		if x[node.feature] <= node.threshold {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return node.value
+}
+
+// histBin accumulates the gradients, hessians and row count of the rows of a node falling into one bin.
+type histBin struct {
+	grad, hess float64
+	count      int
+}
+
+// growingLeaf is a leaf of the tree being grown, with its rows, its histogram per feature and its best split.
+type growingLeaf struct {
+	node             *treeNode
+	rows             []int
+	hist             [][]histBin
+	sumGrad, sumHess float64
+
+	gain     float64
+	feature  int // -1 when the leaf cannot be split
+	splitBin uint8
+}
+
+// treeGrower grows one tree on the binned training rows, given the gradients and hessians of the loss.
+type treeGrower struct {
+	binned     [][]uint8 // Feature major: binned[f][row]
+	edges      [][]float64
+	grad, hess []float64
+
+	maxLeafNodes   int
+	minSamplesLeaf int
+	l2             float64
+}
+
+func (g *treeGrower) histogram(rows []int) [][]histBin {
+	hist := make([][]histBin, len(g.binned))
+	for f, bins := range g.binned {
+		hist[f] = make([]histBin, len(g.edges[f])+1)
+		for _, row := range rows {
+			b := &hist[f][bins[row]]
+			b.grad += g.grad[row]
+			b.hess += g.hess[row]
+			b.count++
+		}
+	}
+	return hist
+}
+
+// subtract returns the histogram of a sibling from those of its parent and of the other child.
+func subtract(parent, child [][]histBin) [][]histBin {
+	hist := make([][]histBin, len(parent))
+	for f := range parent {
+		hist[f] = make([]histBin, len(parent[f]))
+		for b := range parent[f] {
+			hist[f][b] = histBin{
+				grad:  parent[f][b].grad - child[f][b].grad,
+				hess:  parent[f][b].hess - child[f][b].hess,
+				count: parent[f][b].count - child[f][b].count,
+			}
+		}
+	}
+	return hist
+}
+
+func (g *treeGrower) newLeaf(rows []int, hist [][]histBin) *growingLeaf {
+	leaf := &growingLeaf{node: &treeNode{isLeaf: true}, rows: rows, hist: hist, feature: -1}
+	for _, b := range hist[0] {
+		leaf.sumGrad += b.grad
+		leaf.sumHess += b.hess
+	}
+	g.findSplit(leaf)
+	return leaf
+}
+
+// score is the loss reduction, up to a factor 1/2, of giving a leaf its optimal value -G / (H + l2).
+func (g *treeGrower) score(sumGrad, sumHess float64) float64 {
+	return sumGrad * sumGrad / (sumHess + g.l2)
+}
+
+// findSplit scans the cumulative histogram of every feature for the split with the largest gain.
+func (g *treeGrower) findSplit(leaf *growingLeaf) {
+	if len(leaf.rows) < 2*g.minSamplesLeaf {
+		return
+	}
+	parentScore := g.score(leaf.sumGrad, leaf.sumHess)
+
+	for f, bins := range leaf.hist {
+		leftGrad, leftHess, leftCount := 0.0, 0.0, 0
+		for b := 0; b < len(bins)-1; b++ {
+			leftGrad += bins[b].grad
+			leftHess += bins[b].hess
+			leftCount += bins[b].count
+			if leftCount < g.minSamplesLeaf {
+				continue
+			}
+			if len(leaf.rows)-leftCount < g.minSamplesLeaf {
+				break
+			}
+
+			gain := g.score(leftGrad, leftHess) + g.score(leaf.sumGrad-leftGrad, leaf.sumHess-leftHess) - parentScore
+			if gain > leaf.gain {
+				leaf.gain = gain
+				leaf.feature = f
+				leaf.splitBin = uint8(b)
+			}
+		}
+	}
+}
+
+// split turns a leaf into an internal node and returns its two children. Only the histogram of the smaller child
+// is built from its rows; the other one is the difference with the parent's.
+func (g *treeGrower) split(leaf *growingLeaf) (*growingLeaf, *growingLeaf) {
+	var leftRows, rightRows []int
+	for _, row := range leaf.rows {
+		if g.binned[leaf.feature][row] <= leaf.splitBin {
+			leftRows = append(leftRows, row)
+		} else {
+			rightRows = append(rightRows, row)
+		}
+	}
+
+	var leftHist, rightHist [][]histBin
+	if len(leftRows) <= len(rightRows) {
+		leftHist = g.histogram(leftRows)
+		rightHist = subtract(leaf.hist, leftHist)
+	} else {
+		rightHist = g.histogram(rightRows)
+		leftHist = subtract(leaf.hist, rightHist)
+	}
+	left := g.newLeaf(leftRows, leftHist)
+	right := g.newLeaf(rightRows, rightHist)
+
+	leaf.node.isLeaf = false
+	leaf.node.feature = leaf.feature
+	leaf.node.threshold = g.edges[leaf.feature][leaf.splitBin]
+	leaf.node.left = left.node
+	leaf.node.right = right.node
+	leaf.hist = nil
+	return left, right
+}
+
+// grow builds a tree best-first: the leaf with the largest gain is split until there are maxLeafNodes leaves or no
+// split reduces the loss. Leaves get the value -learningRate * G / (H + l2) and are returned with their rows.
+func (g *treeGrower) grow(rows []int, learningRate float64) (*treeNode, []*growingLeaf) {
+	root := g.newLeaf(rows, g.histogram(rows))
+	leaves := []*growingLeaf{root}
+
+	for len(leaves) < g.maxLeafNodes {
+		best := -1
+		for i, leaf := range leaves {
+			if leaf.feature != -1 && (best == -1 || leaf.gain > leaves[best].gain) {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+		left, right := g.split(leaves[best])
+		leaves[best] = left
+		leaves = append(leaves, right)
+	}
+
+	for _, leaf := range leaves {
+		value := -learningRate * leaf.sumGrad / (leaf.sumHess + g.l2)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			value = 0.0
+		}
+		leaf.node.value = value
+		leaf.hist = nil
+	}
+	return root.node, leaves
+}
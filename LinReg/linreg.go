@@ -3,6 +3,8 @@ package LinReg
 import (
 	"GoML/Ensemble"
 	"GoML/metrics"
+	"fmt"
+	"math"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -12,7 +14,10 @@ type LinReg struct {
 	Y     []float64
 	Coefs []float64
 
-	Metrics metrics.Metrics
+	Weights []float64 // Per-row sample weights, nil for unweighted least squares
+
+	Metrics   metrics.Metrics
+	Inference metrics.Inference // No intercept, R2 is uncentered
 }
 
 func NewLinReg(X [][]float64, Y []float64) Ensemble.Estimator {
@@ -40,14 +45,40 @@ func NewLinReg(X [][]float64, Y []float64) Ensemble.Estimator {
 
 }
 
+// NewWeightedLinReg creates a linear regressor minimizing sum_i w_i * (y_i - x_i*beta)^2.
+// Weights are typically the inverse variance (reliability) of each row and must be positive.
+func NewWeightedLinReg(X [][]float64, Y []float64, weights []float64) Ensemble.Estimator {
+	if len(weights) != len(Y) {
+		panic("Weights must have the same number of rows as Y")
+	}
+	for i, w := range weights {
+		if w <= 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			panic(fmt.Sprintf("Weights must be positive and finite, got %v at index %d", w, i))
+		}
+	}
+
+	lr := NewLinReg(X, Y).(*LinReg)
+	lr.Weights = make([]float64, len(weights))
+	copy(lr.Weights, weights)
+	return lr
+}
+
 func (lr *LinReg) Fit() {
 	var xFlattened []float64
-	for _, row := range lr.X {
-		xFlattened = append(xFlattened, row...)
+	yWeighted := make([]float64, len(lr.Y))
+	for i, row := range lr.X {
+		sqrtWeight := 1.0
+		if lr.Weights != nil {
+			sqrtWeight = math.Sqrt(lr.Weights[i])
+		}
+		for _, val := range row {
+			xFlattened = append(xFlattened, val*sqrtWeight)
+		}
+		yWeighted[i] = lr.Y[i] * sqrtWeight
 	}
 
 	xMatrix := mat.NewDense(len(lr.X), len(lr.X[0]), xFlattened)
-	yMatrix := mat.NewVecDense(len(lr.Y), lr.Y)
+	yMatrix := mat.NewVecDense(len(lr.Y), yWeighted)
 
 	var svd mat.SVD
 	ok := svd.Factorize(xMatrix, mat.SVDThin)
@@ -74,7 +105,8 @@ func (lr *LinReg) Fit() {
 		preds[i] = lr.Predict(lr.X[i])
 	}
 
-	lr.Metrics = metrics.Evaluate(lr.Y, preds)
+	lr.Metrics = metrics.EvaluateWeighted(lr.Y, preds, lr.Weights)
+	lr.Inference = metrics.Infer(&svd, rank, lr.Y, preds, lr.Coefs, lr.Weights, false)
 }
 
 func (lr *LinReg) Predict(x []float64) float64 {
@@ -108,6 +140,11 @@ func PredictorFromFitted(lr *LinReg, x []float64) func(x []float64) float64 {
 	}
 }
 
+// Summary returns a text table of the fitted coefficients and their inference statistics.
+func (lr *LinReg) Summary() string {
+	return lr.Inference.Summary("LinReg Regression Results", nil)
+}
+
 func (lr *LinReg) GetMetrics() metrics.Metrics {
 	return lr.Metrics
 }
@@ -0,0 +1,157 @@
+package LogReg
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	lbfgsMemory  = 10    // Number of (s, y) correction pairs kept
+	armijoC      = 1e-4  // Sufficient decrease constant of the line search
+	curvatureC   = 0.9   // Curvature constant of the (weak) Wolfe conditions
+	stallTol     = 1e-12 // Relative decrease below which an iteration counts as stalled
+	stallPatient = 20    // Stalled iterations in a row before the objective is considered converged
+	maxLineSteps = 60    // Bracketing steps before the line search gives up
+	minCurvature = 1e-10 // Pairs with s*y below this fraction of |s|*|y| are ill-conditioned and skipped
+)
+
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func maxAbs(values []float64) float64 {
+	out := 0.0
+	for _, val := range values {
+		out = math.Max(out, math.Abs(val))
+	}
+	return out
+}
+
+// minimizeLBFGS minimizes the smooth function f, which returns f(x) and writes its gradient into grad, starting from
+// x0. It uses limited memory BFGS with a weak Wolfe line search and stops once the largest gradient
+// component drops below gradTol, the objective stops decreasing, or after maxIter iterations.
+func minimizeLBFGS(f func(x, grad []float64) float64, x0 []float64, maxIter int, gradTol float64) (x []float64, nIter int, converged bool) {
+	n := len(x0)
+	x = slices.Clone(x0)
+	grad := make([]float64, n)
+	fx := f(x, grad)
+	if maxAbs(grad) < gradTol {
+		return x, 0, true
+	}
+
+	var sHist, yHist [][]float64
+	var rhoHist []float64
+	direction := make([]float64, n)
+	xNew := make([]float64, n)
+	gradNew := make([]float64, n)
+	coefs := make([]float64, lbfgsMemory)
+	stalled := 0
+
+	for nIter = 1; nIter <= maxIter; nIter++ {
+		// Two-loop recursion for direction = -H * grad
+		copy(direction, grad)
+		for k := len(sHist) - 1; k >= 0; k-- {
+			coefs[k] = rhoHist[k] * dot(sHist[k], direction)
+			for i := range direction {
+				direction[i] -= coefs[k] * yHist[k][i]
+			}
+		}
+		scale := 1 / math.Max(1, maxAbs(grad)) // Keeps the first step from overshooting
+		if last := len(sHist) - 1; last >= 0 {
+			scale = dot(sHist[last], yHist[last]) / dot(yHist[last], yHist[last])
+		}
+		for i := range direction {
+			direction[i] *= scale
+		}
+		for k := range sHist {
+			b := rhoHist[k] * dot(yHist[k], direction)
+			for i := range direction {
+				direction[i] += sHist[k][i] * (coefs[k] - b)
+			}
+		}
+		for i := range direction {
+			direction[i] = -direction[i]
+		}
+
+		slope := dot(grad, direction)
+		if slope >= 0 {
+			// Not a descent direction, restart from steepest descent
+			sHist, yHist, rhoHist = nil, nil, nil
+			for i := range direction {
+				direction[i] = -grad[i] * scale
+			}
+			slope = dot(grad, direction)
+		}
+
+		// Bisect a bracket [lo, hi] until the step satisfies the weak Wolfe conditions, doubling while no upper end
+		// is known. Unlike plain backtracking this also lengthens steps, and it guarantees s*y > 0.
+		step, lo, hi := 1.0, 0.0, math.Inf(1)
+		fNew, accepted := 0.0, false
+		for lineStep := 0; lineStep < maxLineSteps && !accepted; lineStep++ {
+			for i := range xNew {
+				xNew[i] = x[i] + step*direction[i]
+			}
+			fNew = f(xNew, gradNew)
+			switch {
+			case !(fNew <= fx+armijoC*step*slope):
+				hi = step
+			case dot(gradNew, direction) < curvatureC*slope:
+				lo = step
+			default:
+				accepted = true
+				continue
+			}
+			if math.IsInf(hi, 1) {
+				step = 2 * lo
+			} else {
+				step = (lo + hi) / 2
+			}
+		}
+		if !accepted {
+			if lo == 0 {
+				// No step decreases the objective any further
+				return x, nIter, maxAbs(grad) < gradTol
+			}
+			// Fall back to the longest step known to decrease the objective enough
+			step = lo
+			for i := range xNew {
+				xNew[i] = x[i] + step*direction[i]
+			}
+			fNew = f(xNew, gradNew)
+		}
+
+		s := make([]float64, n)
+		y := make([]float64, n)
+		for i := range s {
+			s[i] = xNew[i] - x[i]
+			y[i] = gradNew[i] - grad[i]
+		}
+		if sy := dot(s, y); sy > minCurvature*math.Sqrt(dot(s, s)*dot(y, y)) {
+			sHist, yHist, rhoHist = append(sHist, s), append(yHist, y), append(rhoHist, 1/sy)
+			if len(sHist) > lbfgsMemory {
+				sHist, yHist, rhoHist = sHist[1:], yHist[1:], rhoHist[1:]
+			}
+		}
+
+		decrease := fx - fNew
+		copy(x, xNew)
+		copy(grad, gradNew)
+		fx = fNew
+		if maxAbs(grad) < gradTol {
+			return x, nIter, true
+		}
+		if decrease <= stallTol*math.Max(1, math.Abs(fx)) {
+			stalled++
+			if stalled >= stallPatient {
+				return x, nIter, true
+			}
+		} else {
+			stalled = 0
+		}
+	}
+	return x, maxIter, false
+}
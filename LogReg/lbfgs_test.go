@@ -0,0 +1,23 @@
+package LogReg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinimizeLBFGSRosenbrock(t *testing.T) {
+	rosenbrock := func(x, grad []float64) float64 {
+		a, b := 1-x[0], x[1]-x[0]*x[0]
+		grad[0] = -2*a - 400*x[0]*b
+		grad[1] = 200 * b
+		return a*a + 100*b*b
+	}
+
+	x, nIter, converged := minimizeLBFGS(rosenbrock, []float64{-1.2, 1}, 1000, 1e-8)
+	if !converged {
+		t.Fatalf("did not converge in %d iterations, stopped at %v", nIter, x)
+	}
+	if math.Abs(x[0]-1) > 1e-6 || math.Abs(x[1]-1) > 1e-6 {
+		t.Errorf("minimum = %v, want [1 1]", x)
+	}
+}
@@ -0,0 +1,267 @@
+package LogReg
+
+import (
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// LogReg is L2 regularized logistic regression. Two classes fit a binary model, P(y = Classes[1]) = sigmoid(b + w*x);
+// more classes fit a multinomial (softmax) model with one coefficient row per class.
+// The fit minimizes sum_i -log P(y_i) + Alpha/2 * ||w||^2, leaving intercepts unpenalized (Alpha = 1/C in scikit-learn terms),
+// with L-BFGS.
+type LogReg struct {
+	X            [][]float64 `json:"X,omitempty"`
+	Y            []float64   `json:"y,omitempty"`
+	Classes      []float64   `json:"classes"`
+	Coefs        [][]float64 `json:"coefs"`      // One row per class, or a single row for the positive class when binary
+	Intercepts   []float64   `json:"intercepts"` // Aligned with Coefs
+	Alpha        float64     `json:"alpha"`
+	FitIntercept bool        `json:"fit_intercept"`
+	MaxIter      int         `json:"max_iter"`
+	Tol          float64     `json:"tol"` // Gradient threshold of the mean loss
+
+	// Fit results
+	Multinomial bool `json:"multinomial"`
+	NIter       int  `json:"n_iter"`
+	Converged   bool `json:"converged"`
+
+	Metrics               metrics.Metrics // Regression metrics of the predicted labels, for Estimator compatibility
+	ClassificationMetrics metrics.ClassificationMetrics
+}
+
+func NewLogReg(X [][]float64, Y []float64, alpha float64, fitIntercept bool, maxIter int, tol float64) Ensemble.Estimator {
+	if len(X) == 0 || len(Y) == 0 {
+		panic("X and Y cannot be empty")
+	}
+	if len(X) != len(Y) {
+		panic("X and Y must have the same number of rows")
+	}
+	if alpha < 0 {
+		panic("Alpha must be non-negative")
+	}
+	if maxIter <= 0 {
+		panic("MaxIter must be positive")
+	}
+
+	preAllocX := make([][]float64, len(X))
+	for i := range X {
+		if len(X[i]) == 0 {
+			panic("X cannot have empty rows")
+		}
+		preAllocX[i] = make([]float64, len(X[i]))
+		copy(preAllocX[i], X[i])
+	}
+
+	// Labels are kept as is (no Div0 guard), 0 is a class like any other
+	preAllocY := make([]float64, len(Y))
+	for i, val := range Y {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			panic(fmt.Sprintf("Y contains NaN or Inf at index %d", i))
+		}
+		preAllocY[i] = val
+	}
+
+	classes := metrics.UniqueClasses(preAllocY)
+	if len(classes) < 2 {
+		panic("Y must contain at least two classes")
+	}
+
+	return &LogReg{
+		X:            preAllocX,
+		Y:            preAllocY,
+		Classes:      classes,
+		Alpha:        alpha,
+		FitIntercept: fitIntercept,
+		MaxIter:      maxIter,
+		Tol:          tol,
+		Multinomial:  len(classes) > 2,
+	}
+}
+
+func NewDefaultLogReg(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewLogReg(X, Y, 1.0, true, 500, 1e-6)
+}
+
+// nOutputs is the number of linear scores: one for a binary model, one per class for a multinomial model.
+func (lr *LogReg) nOutputs() int {
+	if lr.Multinomial {
+		return len(lr.Classes)
+	}
+	return 1
+}
+
+// scores computes the linear scores of x for the packed parameters [b_0, w_0..., b_1, w_1..., ...].
+func scores(params, x []float64, nOutputs int, out []float64) {
+	stride := len(x) + 1
+	for k := 0; k < nOutputs; k++ {
+		row := params[k*stride : (k+1)*stride]
+		z := row[0]
+		for j, val := range x {
+			z += row[j+1] * val
+		}
+		out[k] = z
+	}
+}
+
+// probabilities turns linear scores into class probabilities, in place for multinomial models.
+func probabilities(z []float64, multinomial bool) []float64 {
+	if !multinomial {
+		p := 1 / (1 + math.Exp(-z[0]))
+		return []float64{1 - p, p}
+	}
+	maxZ := slices.Max(z)
+	sum := 0.0
+	for k := range z {
+		z[k] = math.Exp(z[k] - maxZ)
+		sum += z[k]
+	}
+	for k := range z {
+		z[k] /= sum
+	}
+	return z
+}
+
+// objective returns the mean penalized negative log-likelihood and writes its gradient into grad when non-nil.
+func (lr *LogReg) objective(params, grad []float64, targets []int) float64 {
+	nRows := float64(len(lr.Y))
+	nFeatures := len(lr.X[0])
+	stride := nFeatures + 1
+	nOut := lr.nOutputs()
+
+	if grad != nil {
+		for i := range grad {
+			grad[i] = 0
+		}
+	}
+
+	loss := 0.0
+	z := make([]float64, nOut)
+	for i, row := range lr.X {
+		scores(params, row, nOut, z)
+		if !lr.Multinomial {
+			// -log P(y) in a form that does not overflow for large |z|
+			t := float64(targets[i])
+			loss += math.Log1p(math.Exp(-math.Abs(z[0]))) + math.Max(z[0], 0) - t*z[0]
+		} else {
+			maxZ := slices.Max(z)
+			logSum := 0.0
+			for _, val := range z {
+				logSum += math.Exp(val - maxZ)
+			}
+			loss += maxZ + math.Log(logSum) - z[targets[i]]
+		}
+
+		if grad == nil {
+			continue
+		}
+		proba := probabilities(z, lr.Multinomial)
+		for k := 0; k < nOut; k++ {
+			var residual float64
+			if lr.Multinomial {
+				residual = proba[k]
+				if targets[i] == k {
+					residual--
+				}
+			} else {
+				residual = proba[1] - float64(targets[i])
+			}
+			g := grad[k*stride : (k+1)*stride]
+			if lr.FitIntercept {
+				g[0] += residual
+			}
+			for j, val := range row {
+				g[j+1] += residual * val
+			}
+		}
+	}
+
+	for k := 0; k < nOut; k++ {
+		for j := 1; j < stride; j++ {
+			w := params[k*stride+j]
+			loss += lr.Alpha / 2 * w * w
+			if grad != nil {
+				grad[k*stride+j] += lr.Alpha * w
+			}
+		}
+	}
+
+	if grad != nil {
+		for i := range grad {
+			grad[i] /= nRows
+		}
+	}
+	return loss / nRows
+}
+
+func (lr *LogReg) Fit() {
+	nFeatures := len(lr.X[0])
+	stride := nFeatures + 1
+	nOut := lr.nOutputs()
+
+	targets := make([]int, len(lr.Y))
+	for i, label := range lr.Y {
+		targets[i], _ = slices.BinarySearch(lr.Classes, label)
+	}
+
+	objective := func(params, grad []float64) float64 {
+		return lr.objective(params, grad, targets)
+	}
+	params, nIter, converged := minimizeLBFGS(objective, make([]float64, nOut*stride), lr.MaxIter, lr.Tol)
+	lr.NIter = nIter
+	lr.Converged = converged
+
+	lr.Coefs = make([][]float64, nOut)
+	lr.Intercepts = make([]float64, nOut)
+	for k := 0; k < nOut; k++ {
+		lr.Intercepts[k] = params[k*stride]
+		lr.Coefs[k] = slices.Clone(params[k*stride+1 : (k+1)*stride])
+	}
+
+	preds := make([]float64, len(lr.Y))
+	proba := make([][]float64, len(lr.Y))
+	for i, row := range lr.X {
+		proba[i] = lr.PredictProba(row)
+		preds[i] = lr.Classes[metrics.ArgMax(proba[i])]
+	}
+	lr.Metrics = metrics.Evaluate(lr.Y, preds)
+	lr.ClassificationMetrics = metrics.EvaluateClassifier(lr.Y, preds, proba, lr.Classes)
+}
+
+// PredictProba returns the probability of each class, in GetClasses order.
+func (lr *LogReg) PredictProba(x []float64) []float64 {
+	if len(x) != len(lr.Coefs[0]) {
+		panic("Input feature length does not match number of coefficients")
+	}
+
+	z := make([]float64, len(lr.Coefs))
+	for k, coefs := range lr.Coefs {
+		z[k] = lr.Intercepts[k]
+		for j, coef := range coefs {
+			z[k] += coef * x[j]
+		}
+	}
+	return probabilities(z, lr.Multinomial)
+}
+
+func (lr *LogReg) PredictClass(x []float64) float64 {
+	return lr.Classes[metrics.ArgMax(lr.PredictProba(x))]
+}
+
+func (lr *LogReg) Predict(x []float64) float64 {
+	return lr.PredictClass(x)
+}
+
+func (lr *LogReg) GetClasses() []float64 {
+	return lr.Classes
+}
+
+func (lr *LogReg) GetMetrics() metrics.Metrics {
+	return lr.Metrics
+}
+
+func (lr *LogReg) GetClassificationMetrics() metrics.ClassificationMetrics {
+	return lr.ClassificationMetrics
+}
@@ -0,0 +1,182 @@
+package LogReg
+
+import (
+	"GoML/metrics"
+	"GoML/parser"
+	"math"
+	"testing"
+)
+
+// loadHousing returns RM and LSTAT with MEDV cut into classes at the given thresholds.
+func loadHousing(thresholds ...float64) ([][]float64, []float64) {
+	data := parser.LoadData("../test_data.csv", ",", true, 13)
+	x := make([][]float64, len(data.X))
+	y := make([]float64, len(data.Y))
+	for i, row := range data.X {
+		x[i] = []float64{row[5], row[12]}
+		for _, threshold := range thresholds {
+			if data.Y[i] > threshold {
+				y[i]++
+			}
+		}
+	}
+	return x, y
+}
+
+func assertClose(t *testing.T, name string, got, want, tol float64) {
+	t.Helper()
+	if math.Abs(got-want) > tol {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+// Reference values minimize the same objective with Newton's method to a gradient below 1e-13.
+func TestLogRegReference(t *testing.T) {
+	cases := []struct {
+		name       string
+		thresholds []float64
+		alpha      float64
+		intercepts []float64
+		coefs      [][]float64
+		proba      map[int][]float64 // Row to class probabilities
+	}{
+		{
+			name: "binary", thresholds: []float64{21}, alpha: 0,
+			intercepts: []float64{-9.210209565538374},
+			coefs:      [][]float64{{1.8418657605181539, -0.21069043717093158}},
+			proba:      map[int][]float64{0: {0.13577671492250798, 0.864223285077492}, 5: {0.17722099950530334, 0.8227790004946967}},
+		},
+		{
+			name: "binary l2", thresholds: []float64{21}, alpha: 1,
+			intercepts: []float64{-0.6542373707565489},
+			coefs:      [][]float64{{0.5656745509276055, -0.2675786198213365}},
+			proba:      map[int][]float64{0: {0.15026408823185944, 0.8497359117681406}, 5: {0.169529510170341, 0.830470489829659}},
+		},
+		{
+			// Softmax intercepts are only defined up to a common shift, so they are compared centered
+			name: "multinomial l2", thresholds: []float64{16.99, 21.99}, alpha: 1,
+			intercepts: []float64{-2.6613417620070177, 3.7322891413243986, -1.052421228004006},
+			coefs: [][]float64{
+				{-0.4469221526129333, 0.3401846697128877},
+				{-0.25439051470098367, -0.09800892391409405},
+				{0.7013126673139145, -0.24217574579879453},
+			},
+			proba: map[int][]float64{
+				0:  {0.0013113157950702765, 0.3136737343644554, 0.6850149498404743},
+				5:  {0.001671840701741643, 0.35161820721364145, 0.646709952084617},
+				20: {0.8409462284355267, 0.14689683074893067, 0.012156940815542687},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := loadHousing(tt.thresholds...)
+			lr := NewLogReg(x, y, tt.alpha, true, 1000, 1e-10).(*LogReg)
+			lr.Fit()
+			if !lr.Converged {
+				t.Fatalf("did not converge in %d iterations", lr.NIter)
+			}
+
+			center := func(values []float64) []float64 {
+				if len(values) == 1 {
+					return values
+				}
+				mean := 0.0
+				for _, v := range values {
+					mean += v / float64(len(values))
+				}
+				out := make([]float64, len(values))
+				for k, v := range values {
+					out[k] = v - mean
+				}
+				return out
+			}
+			want := center(tt.intercepts)
+			for k, intercept := range center(lr.Intercepts) {
+				assertClose(t, "intercept", intercept, want[k], 1e-6)
+				for j, coef := range lr.Coefs[k] {
+					assertClose(t, "coef", coef, tt.coefs[k][j], 1e-6)
+				}
+			}
+			for row, want := range tt.proba {
+				for k, p := range lr.PredictProba(x[row]) {
+					assertClose(t, "probability", p, want[k], 1e-7)
+				}
+			}
+		})
+	}
+}
+
+func TestPredictProbaSumsToOne(t *testing.T) {
+	for _, thresholds := range [][]float64{{21}, {16.99, 21.99}} {
+		x, y := loadHousing(thresholds...)
+		lr := NewDefaultLogReg(x, y).(*LogReg)
+		lr.Fit()
+		for i, row := range x {
+			proba := lr.PredictProba(row)
+			if len(proba) != len(lr.Classes) {
+				t.Fatalf("%d probabilities for %d classes", len(proba), len(lr.Classes))
+			}
+			sum := 0.0
+			for _, p := range proba {
+				if p < 0 || p > 1 {
+					t.Errorf("row %d: probability %v outside [0, 1]", i, p)
+				}
+				sum += p
+			}
+			assertClose(t, "probability sum", sum, 1, 1e-12)
+			if got, want := lr.PredictClass(row), lr.Classes[metrics.ArgMax(proba)]; got != want {
+				t.Errorf("row %d: class %v, most probable %v", i, got, want)
+			}
+		}
+	}
+}
+
+// The L-BFGS solution of the logistic objective is a stationary point, and no worse than a point perturbed from it.
+func TestMinimizeLBFGSLogistic(t *testing.T) {
+	x, y := loadHousing(16.99, 21.99)
+	lr := NewLogReg(x, y, 0.1, true, 1000, 1e-9).(*LogReg)
+	targets := make([]int, len(y))
+	for i, label := range y {
+		targets[i] = int(label)
+	}
+	objective := func(params, grad []float64) float64 {
+		return lr.objective(params, grad, targets)
+	}
+
+	params, nIter, converged := minimizeLBFGS(objective, make([]float64, 9), lr.MaxIter, lr.Tol)
+	if !converged {
+		t.Fatalf("did not converge in %d iterations", nIter)
+	}
+	grad := make([]float64, len(params))
+	best := objective(params, grad)
+	if maxAbs(grad) > 1e-9 {
+		t.Errorf("gradient %v at the solution", grad)
+	}
+
+	// A central finite difference agrees with the analytic gradient away from the optimum
+	point := make([]float64, len(params))
+	for i := range point {
+		point[i] = 0.1 * float64(i%4-1)
+	}
+	objective(point, grad)
+	for i := range point {
+		plus, minus := make([]float64, len(point)), make([]float64, len(point))
+		copy(plus, point)
+		copy(minus, point)
+		plus[i] += 1e-6
+		minus[i] -= 1e-6
+		numeric := (objective(plus, nil) - objective(minus, nil)) / 2e-6
+		assertClose(t, "gradient", grad[i], numeric, 1e-6)
+	}
+
+	for i := range params {
+		perturbed := make([]float64, len(params))
+		copy(perturbed, params)
+		perturbed[i] += 1e-3
+		if objective(perturbed, nil) < best {
+			t.Errorf("moving parameter %d lowers the objective below the solution", i)
+		}
+	}
+}
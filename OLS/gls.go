@@ -0,0 +1,176 @@
+package OLS
+
+import (
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Covariance describes the residual covariance structure of a GLS fit, known up to a scale factor.
+type Covariance interface {
+	// Whiten applies the inverse Cholesky factor of the covariance to v, decorrelating its rows.
+	Whiten(v []float64) []float64
+	// LogDet returns the log-determinant of the n x n covariance.
+	LogDet(n int) float64
+}
+
+// AR1 is the covariance of first order autoregressive errors e_t = Rho * e_{t-1} + u_t, with rows in time order.
+type AR1 struct {
+	Rho float64 `json:"rho"`
+}
+
+// Whiten applies the Prais-Winsten transform, which keeps the first row instead of dropping it.
+func (c AR1) Whiten(v []float64) []float64 {
+	out := make([]float64, len(v))
+	if len(v) == 0 {
+		return out
+	}
+	out[0] = math.Sqrt(1-c.Rho*c.Rho) * v[0]
+	for t := 1; t < len(v); t++ {
+		out[t] = v[t] - c.Rho*v[t-1]
+	}
+	return out
+}
+
+func (c AR1) LogDet(n int) float64 {
+	return -math.Log(1 - c.Rho*c.Rho)
+}
+
+// FullCovariance is an arbitrary symmetric positive definite residual covariance.
+type FullCovariance struct {
+	Sigma *mat.SymDense
+	chol  mat.Cholesky
+}
+
+func NewFullCovariance(sigma [][]float64) *FullCovariance {
+	n := len(sigma)
+	flattened := make([]float64, 0, n*n)
+	for _, row := range sigma {
+		if len(row) != n {
+			panic("Covariance matrix must be square")
+		}
+		flattened = append(flattened, row...)
+	}
+
+	c := &FullCovariance{Sigma: mat.NewSymDense(n, flattened)}
+	ok := c.chol.Factorize(c.Sigma)
+	if !ok {
+		panic("Covariance matrix is not positive definite")
+	}
+	return c
+}
+
+func (c *FullCovariance) Whiten(v []float64) []float64 {
+	var l mat.TriDense
+	c.chol.LTo(&l)
+
+	var z mat.VecDense
+	err := z.SolveVec(&l, mat.NewVecDense(len(v), v))
+	if err != nil {
+		panic(err)
+	}
+	return z.RawVector().Data
+}
+
+func (c *FullCovariance) LogDet(n int) float64 {
+	return c.chol.LogDet()
+}
+
+// EstimateAR1Rho estimates the lag-1 autocorrelation of residuals in time order.
+func EstimateAR1Rho(resid []float64) float64 {
+	num, den := 0.0, 0.0
+	for t := 1; t < len(resid); t++ {
+		num += resid[t] * resid[t-1]
+		den += resid[t-1] * resid[t-1]
+	}
+	if den == 0 {
+		return 0
+	}
+	return math.Max(-0.999, math.Min(0.999, num/den))
+}
+
+// GLS is generalized least squares: OLS on data whitened by the residual covariance structure.
+// Predictions, coefficients and metrics are on the original scale; Inference is computed on the whitened system.
+type GLS struct {
+	OLS
+	Covariance Covariance `json:"covariance"` // Nil for feasible GLS
+
+	// Fit results
+	FittedCovariance Covariance `json:"fitted_covariance"` // Covariance, or the AR(1) estimated by feasible GLS
+}
+
+// NewGLS creates a GLS regressor for the given covariance structure. With a nil covariance, Fit runs feasible GLS:
+// it estimates an AR(1) structure from the residuals of an initial OLS fit.
+func NewGLS(X [][]float64, Y []float64, covariance Covariance) Ensemble.Estimator {
+	ols := NewOLS(X, Y).(*OLS)
+	return &GLS{
+		OLS:        *ols,
+		Covariance: covariance,
+	}
+}
+
+func (g *GLS) Fit() {
+	// Estimated into FittedCovariance so that a refit estimates rho again
+	g.FittedCovariance = g.Covariance
+	if g.Covariance == nil {
+		g.OLS.Fit()
+		resid := make([]float64, len(g.Y))
+		for i, row := range g.X {
+			resid[i] = g.Y[i] - g.Predict(row[1:])
+		}
+		g.FittedCovariance = AR1{Rho: EstimateAR1Rho(resid)}
+	}
+	covariance := g.FittedCovariance
+
+	nRows, nCols := len(g.X), len(g.X[0])
+
+	xWhitened := mat.NewDense(nRows, nCols, nil)
+	column := make([]float64, nRows)
+	for j := 0; j < nCols; j++ {
+		for i, row := range g.X {
+			column[i] = row[j]
+		}
+		xWhitened.SetCol(j, covariance.Whiten(column))
+	}
+	yWhitened := covariance.Whiten(g.Y)
+
+	var svd mat.SVD
+	ok := svd.Factorize(xWhitened, mat.SVDThin)
+	if !ok {
+		panic("SVD Factorization Failed")
+	}
+
+	eps := 1e-8
+	rank := 0
+	for _, s := range svd.Values(nil) {
+		if s > eps {
+			rank++
+		}
+	}
+
+	var beta mat.Dense
+	svd.SolveTo(&beta, mat.NewVecDense(nRows, yWhitened), rank)
+
+	raw := beta.RawMatrix().Data
+	g.Intercept = raw[0]
+	g.Coefs = raw[1:]
+
+	preds := make([]float64, nRows)
+	for i := 0; i < nRows; i++ {
+		preds[i] = g.Predict(g.X[i][1:])
+	}
+	g.Metrics = metrics.Evaluate(g.Y, preds)
+
+	g.Inference = metrics.Infer(&svd, rank, yWhitened, covariance.Whiten(preds), raw, nil, true)
+	// Whitening contributes -log|det(Sigma)|/2 to the Gaussian log-likelihood
+	g.Inference.LogLikelihood -= covariance.LogDet(nRows) / 2
+	g.Inference.AIC = -2*g.Inference.LogLikelihood + 2*float64(rank)
+	g.Inference.BIC = -2*g.Inference.LogLikelihood + math.Log(float64(nRows))*float64(rank)
+}
+
+// Summary returns a text table of the fitted parameters and their inference statistics.
+func (g *GLS) Summary() string {
+	return g.Inference.Summary("GLS Regression Results", nil)
+}
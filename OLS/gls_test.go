@@ -0,0 +1,48 @@
+package OLS
+
+import "testing"
+
+// Reference values are a Prais-Winsten fit with rho estimated from the OLS residuals of TestOLSInference.
+func TestFeasibleGLS(t *testing.T) {
+	x, y := loadHousing()
+	gls := NewGLS(x, y, nil).(*GLS)
+
+	// Refitting must estimate rho again rather than reuse the first estimate
+	for fit := 0; fit < 2; fit++ {
+		gls.Fit()
+		if gls.Covariance != nil {
+			t.Fatalf("Fit overwrote the nil covariance with %v", gls.Covariance)
+		}
+		ar, ok := gls.FittedCovariance.(AR1)
+		if !ok {
+			t.Fatalf("FittedCovariance = %T, want AR1", gls.FittedCovariance)
+		}
+		assertClose(t, "rho", ar.Rho, 0.4858704164651257, 1e-8)
+	}
+
+	params := []float64{gls.Intercept, gls.Coefs[0], gls.Coefs[1], gls.Coefs[2]}
+	wantParams := []float64{-4.47014850925734, 5.946781169552001, -0.29470294629708943, -0.41896378232414044}
+	wantStdErrors := []float64{14.28983061588002, 1.8442272006792806, 0.334594102511505, 0.1268894865936807}
+	for j := range wantParams {
+		assertClose(t, "param", params[j], wantParams[j], 1e-8)
+		assertClose(t, "std error", gls.Inference.StdErrors[j], wantStdErrors[j], 1e-8)
+	}
+	assertClose(t, "LogLikelihood", gls.Inference.LogLikelihood, -73.18325327715294, 1e-8)
+}
+
+func TestGLSRefitUsesNewData(t *testing.T) {
+	x, y := loadHousing()
+	gls := NewGLS(x, y, nil).(*GLS)
+	gls.Fit()
+	first := gls.FittedCovariance.(AR1).Rho
+
+	// Reversing the rows keeps the lag-1 products but not the denominator, so rho changes
+	for i, j := 0, len(gls.X)-1; i < j; i, j = i+1, j-1 {
+		gls.X[i], gls.X[j] = gls.X[j], gls.X[i]
+		gls.Y[i], gls.Y[j] = gls.Y[j], gls.Y[i]
+	}
+	gls.Fit()
+	if gls.FittedCovariance.(AR1).Rho == first {
+		t.Errorf("refit reused rho %v", first)
+	}
+}
@@ -26,8 +26,10 @@ type OLS struct {
 	Y         []float64   `json:"y,omitempty"`     //1d-array[float64]
 	Coefs     []float64   `json:"coefs,omitempty"` // 1d-array[float64]
 	Intercept float64     `json:"intercept,omitempty"`
+	Weights   []float64   `json:"weights,omitempty"` // Per-row sample weights (WLS), nil for OLS
 
-	Metrics metrics.Metrics
+	Metrics   metrics.Metrics
+	Inference metrics.Inference // Parameters are ordered as [intercept, coefs...]
 }
 
 func NewOLS(X [][]float64, Y []float64) Ensemble.Estimator {
@@ -79,15 +81,46 @@ func NewOLS(X [][]float64, Y []float64) Ensemble.Estimator {
 	return ols
 }
 
+// NewWLS creates a weighted least squares regressor minimizing sum_i w_i * (y_i - x_i*beta)^2.
+// Weights are typically the inverse variance (reliability) of each row and must be positive.
+func NewWLS(X [][]float64, Y []float64, weights []float64) Ensemble.Estimator {
+	if len(weights) != len(Y) {
+		panic("Weights must have the same number of rows as Y")
+	}
+	for i, w := range weights {
+		if w <= 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			panic(fmt.Sprintf("Weights must be positive and finite, got %v at index %d", w, i))
+		}
+	}
+
+	ols := NewOLS(X, Y).(*OLS)
+	ols.Weights = make([]float64, len(weights))
+	copy(ols.Weights, weights)
+	return ols
+}
+
 func (ols *OLS) Fit() {
 	nRows, nCols := len(ols.X), len(ols.X[0])
 
+	// WLS is solved as OLS on rows scaled by sqrt(w)
+	sqrtWeights := make([]float64, nRows)
+	for i := range sqrtWeights {
+		sqrtWeights[i] = 1.0
+		if ols.Weights != nil {
+			sqrtWeights[i] = math.Sqrt(ols.Weights[i])
+		}
+	}
+
 	xFlattened := make([]float64, 0, nRows*nCols)
-	for _, row := range ols.X {
-		xFlattened = append(xFlattened, row...)
+	yWeighted := make([]float64, nRows)
+	for i, row := range ols.X {
+		for _, val := range row {
+			xFlattened = append(xFlattened, val*sqrtWeights[i])
+		}
+		yWeighted[i] = ols.Y[i] * sqrtWeights[i]
 	}
 	xMatrix := mat.NewDense(nRows, nCols, xFlattened)
-	yVector := mat.NewVecDense(nRows, ols.Y)
+	yVector := mat.NewVecDense(nRows, yWeighted)
 
 	var svd mat.SVD
 	ok := svd.Factorize(xMatrix, mat.SVDThin)
@@ -117,7 +150,8 @@ func (ols *OLS) Fit() {
 		preds[i] = ols.Predict(ols.X[i][1:])
 	}
 
-	ols.Metrics = metrics.Evaluate(ols.Y, preds)
+	ols.Metrics = metrics.EvaluateWeighted(ols.Y, preds, ols.Weights)
+	ols.Inference = metrics.Infer(&svd, rank, ols.Y, preds, raw, ols.Weights, true)
 }
 
 func (ols *OLS) Predict(x []float64) float64 {
@@ -168,6 +202,11 @@ func PredictorFromFitted(ols *OLS, x []float64) func([]float64) float64 {
 	}
 }
 
+// Summary returns a text table of the fitted parameters and their inference statistics.
+func (ols *OLS) Summary() string {
+	return ols.Inference.Summary("OLS Regression Results", nil)
+}
+
 func (ols *OLS) GetMetrics() metrics.Metrics {
 	return ols.Metrics
 }
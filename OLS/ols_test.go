@@ -0,0 +1,60 @@
+package OLS
+
+import (
+	"GoML/parser"
+	"math"
+	"testing"
+)
+
+// Reference values for MEDV ~ RM + PTRATIO + LSTAT on test_data.csv, solved exactly from the normal equations
+// (they agree with statsmodels OLS).
+func loadHousing() ([][]float64, []float64) {
+	data := parser.LoadData("../test_data.csv", ",", true, 13)
+	x := make([][]float64, len(data.X))
+	for i, row := range data.X {
+		x[i] = []float64{row[5], row[10], row[12]}
+	}
+	return x, data.Y
+}
+
+func assertClose(t *testing.T, name string, got, want, tol float64) {
+	t.Helper()
+	if math.Abs(got-want) > tol*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s = %.10g, want %.10g", name, got, want)
+	}
+}
+
+func TestOLSInference(t *testing.T) {
+	x, y := loadHousing()
+	ols := NewOLS(x, y).(*OLS)
+	ols.Fit()
+	inf := ols.Inference
+
+	params := []float64{ols.Intercept, ols.Coefs[0], ols.Coefs[1], ols.Coefs[2]}
+	wantParams := []float64{-1.3944957844802381, 5.94655697757172, -0.4289144898659566, -0.44026377170488673}
+	wantStdErrors := []float64{15.800353960346671, 1.9960468672139946, 0.2618791634138296, 0.14673445076139652}
+	wantTValues := []float64{-0.08825724967807252, 2.979167010177319, -1.6378335881124402, -3.0004117602947615}
+	for j := range wantParams {
+		assertClose(t, "param", params[j], wantParams[j], 1e-8)
+		assertClose(t, "std error", inf.StdErrors[j], wantStdErrors[j], 1e-8)
+		assertClose(t, "t value", inf.TValues[j], wantTValues[j], 1e-8)
+	}
+
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"R2", inf.R2, 0.7085069520089917},
+		{"AdjR2", inf.AdjR2, 0.6748731387792599},
+		{"FStatistic", inf.FStatistic, 21.065317428315957},
+		{"LogLikelihood", inf.LogLikelihood, -77.46823277844906},
+		{"AIC", inf.AIC, 162.93646555689813},
+		{"BIC", inf.BIC, 168.54125508354676},
+	}
+	for _, tt := range tests {
+		assertClose(t, tt.name, tt.got, tt.want, 1e-8)
+	}
+	if inf.NObs != 30 || inf.DFModel != 3 || inf.DFResid != 26 {
+		t.Errorf("NObs, DFModel, DFResid = %d, %d, %d, want 30, 3, 26", inf.NObs, inf.DFModel, inf.DFResid)
+	}
+}
@@ -0,0 +1,152 @@
+package QuantReg
+
+import (
+	"GoML/Ensemble"
+	"GoML/OLS"
+	"GoML/metrics"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// QuantReg is a linear regressor with an intercept that predicts the Quantile-th conditional quantile of Y
+// by minimizing the pinball loss sum_i max(q * r_i, (q - 1) * r_i). Quantile = 0.5 is least absolute deviations.
+// The loss is minimized with iteratively reweighted least squares, each row weighted by q / |r_i| (or (1 - q) / |r_i|
+// for negative residuals) so that the weighted squared loss matches the pinball loss at the current fit.
+type QuantReg struct {
+	X         [][]float64 `json:"X,omitempty"`
+	Y         []float64   `json:"y,omitempty"`
+	Coefs     []float64   `json:"coefs,omitempty"`
+	Intercept float64     `json:"intercept,omitempty"`
+	Quantile  float64     `json:"quantile"`
+	MaxIter   int         `json:"max_iter"`
+	Tol       float64     `json:"tol"`
+
+	// Fit results
+	PinballLoss float64 `json:"pinball_loss"`
+	Coverage    float64 `json:"coverage"` // Share of rows at or below their prediction, close to Quantile for a good fit
+	NIter       int     `json:"n_iter"`
+	Converged   bool    `json:"converged"`
+
+	Metrics metrics.Metrics
+}
+
+func NewQuantReg(X [][]float64, Y []float64, quantile float64, maxIter int, tol float64) Ensemble.Estimator {
+	if len(X) == 0 || len(Y) == 0 {
+		panic("X and Y cannot be empty")
+	}
+	if len(X) != len(Y) {
+		panic("X and Y must have the same number of rows")
+	}
+	if quantile <= 0 || quantile >= 1 {
+		panic("Quantile must be in (0, 1)")
+	}
+	if maxIter <= 0 {
+		panic("MaxIter must be positive")
+	}
+
+	preAllocX := make([][]float64, len(X))
+	for i := range X {
+		if len(X[i]) == 0 {
+			panic("X cannot have empty rows")
+		}
+		preAllocX[i] = make([]float64, len(X[i]))
+		copy(preAllocX[i], X[i])
+	}
+
+	preAllocY := make([]float64, len(Y))
+	for i, val := range Y {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			panic(fmt.Sprintf("Y contains NaN or Inf at index %d", i))
+		}
+		if val == 0.0 {
+			val = 1e-8 // Avoid Div0
+		}
+		preAllocY[i] = val
+	}
+
+	return &QuantReg{
+		X:        preAllocX,
+		Y:        preAllocY,
+		Coefs:    make([]float64, len(X[0])),
+		Quantile: quantile,
+		MaxIter:  maxIter,
+		Tol:      tol,
+	}
+}
+
+// NewDefaultQuantReg creates a median (least absolute deviations) regressor.
+func NewDefaultQuantReg(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewQuantReg(X, Y, 0.5, 500, 1e-6)
+}
+
+func (qr *QuantReg) Fit() {
+	nRows := len(qr.Y)
+	weights := make([]float64, nRows)
+	for i := range weights {
+		weights[i] = 1.0
+	}
+
+	// Residuals are floored at a small fraction of the target scale so rows fitted exactly keep a finite weight
+	yScale := 0.0
+	for _, val := range qr.Y {
+		yScale = math.Max(yScale, math.Abs(val))
+	}
+	floor := 1e-6 * math.Max(yScale, 1.0)
+
+	qr.Converged = false
+	for iter := 1; iter <= qr.MaxIter; iter++ {
+		qr.NIter = iter
+		model := OLS.NewWLS(qr.X, qr.Y, weights).(*OLS.OLS)
+		model.Fit()
+
+		maxDelta := math.Abs(model.Intercept - qr.Intercept)
+		for j, coef := range model.Coefs {
+			maxDelta = math.Max(maxDelta, math.Abs(coef-qr.Coefs[j]))
+		}
+		qr.Intercept = model.Intercept
+		qr.Coefs = slices.Clone(model.Coefs)
+
+		for i, row := range qr.X {
+			r := qr.Y[i] - qr.Predict(row)
+			if r >= 0 {
+				weights[i] = qr.Quantile / math.Max(r, floor)
+			} else {
+				weights[i] = (1 - qr.Quantile) / math.Max(-r, floor)
+			}
+		}
+
+		if iter > 1 && maxDelta < qr.Tol {
+			qr.Converged = true
+			break
+		}
+	}
+
+	preds := make([]float64, nRows)
+	covered := 0
+	for i, row := range qr.X {
+		preds[i] = qr.Predict(row)
+		if qr.Y[i] <= preds[i] {
+			covered++
+		}
+	}
+	qr.Coverage = float64(covered) / float64(nRows)
+	qr.PinballLoss = metrics.PinballLoss(qr.Y, preds, qr.Quantile)
+	qr.Metrics = metrics.Evaluate(qr.Y, preds)
+}
+
+func (qr *QuantReg) Predict(x []float64) float64 {
+	if len(x) != len(qr.Coefs) {
+		panic("Input feature length does not match number of coefficients")
+	}
+
+	pred := qr.Intercept
+	for i, coef := range qr.Coefs {
+		pred += coef * x[i]
+	}
+	return pred
+}
+
+func (qr *QuantReg) GetMetrics() metrics.Metrics {
+	return qr.Metrics
+}
@@ -0,0 +1,98 @@
+package QuantReg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// skewedData draws y = 1 + 2x plus exponential noise, whose quantiles differ from its mean.
+func skewedData(nRows int, seed int64) ([][]float64, []float64) {
+	rng := rand.New(rand.NewSource(seed))
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = []float64{10 * rng.Float64()}
+		y[i] = 1 + 2*x[i][0] + 3*rng.ExpFloat64()
+	}
+	return x, y
+}
+
+func absLoss(x [][]float64, y []float64, intercept, slope float64) float64 {
+	loss := 0.0
+	for i := range y {
+		loss += math.Abs(y[i] - intercept - slope*x[i][0])
+	}
+	return loss
+}
+
+// ladReference minimizes the absolute deviations of a line exactly. Some optimal line passes through two of the
+// points, a vertex of the linear program, so it suffices to try every pair.
+func ladReference(x [][]float64, y []float64) (intercept, slope float64) {
+	best := math.Inf(1)
+	for i := range y {
+		for j := i + 1; j < len(y); j++ {
+			if x[i][0] == x[j][0] {
+				continue
+			}
+			b := (y[j] - y[i]) / (x[j][0] - x[i][0])
+			a := y[i] - b*x[i][0]
+			if loss := absLoss(x, y, a, b); loss < best {
+				best, intercept, slope = loss, a, b
+			}
+		}
+	}
+	return intercept, slope
+}
+
+func TestMedianMatchesLAD(t *testing.T) {
+	x, y := skewedData(41, 1)
+	qr := NewDefaultQuantReg(x, y).(*QuantReg)
+	qr.Fit()
+
+	intercept, slope := ladReference(x, y)
+	want := absLoss(x, y, intercept, slope)
+	got := absLoss(x, y, qr.Intercept, qr.Coefs[0])
+	// The floored residuals of the reweighting leave IRLS just short of the optimum, where the loss is flat
+	if got > want*(1+1e-5) {
+		t.Errorf("absolute loss %v, LAD optimum %v", got, want)
+	}
+	if math.Abs(qr.Intercept-intercept) > 0.02 || math.Abs(qr.Coefs[0]-slope) > 0.005 {
+		t.Errorf("fit %v + %v x, LAD reference %v + %v x", qr.Intercept, qr.Coefs[0], intercept, slope)
+	}
+	if math.Abs(qr.PinballLoss-got/(2*float64(len(y)))) > 1e-12 {
+		t.Errorf("pinball loss %v is not half the mean absolute deviation %v", qr.PinballLoss, got/float64(len(y)))
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	x, y := skewedData(1000, 2)
+	xTest, yTest := skewedData(4000, 3)
+	for _, q := range []float64{0.1, 0.5, 0.9} {
+		qr := NewQuantReg(x, y, q, 500, 1e-6).(*QuantReg)
+		qr.Fit()
+		if math.Abs(qr.Coverage-q) > 0.01 {
+			t.Errorf("q=%v: training coverage %v", q, qr.Coverage)
+		}
+
+		below := 0
+		for i, row := range xTest {
+			if yTest[i] <= qr.Predict(row) {
+				below++
+			}
+		}
+		if share := float64(below) / float64(len(yTest)); math.Abs(share-q) > 0.03 {
+			t.Errorf("q=%v: %v of the held-out targets lie below the prediction", q, share)
+		}
+		// The noise quantile is -3 ln(1 - q), so only the intercept moves with q
+		assertNear(t, q, "slope", qr.Coefs[0], 2, 0.2)
+		assertNear(t, q, "intercept", qr.Intercept, 1-3*math.Log(1-q), 0.6)
+	}
+}
+
+func assertNear(t *testing.T, q float64, name string, got, want, tol float64) {
+	t.Helper()
+	if math.Abs(got-want) > tol {
+		t.Errorf("q=%v: %s = %v, want %v", q, name, got, want)
+	}
+}
@@ -0,0 +1,165 @@
+package Ridge
+
+import (
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+type Ridge struct {
+	X            [][]float64 `json:"X,omitempty"`     // nd-array[float64]
+	Y            []float64   `json:"y,omitempty"`     // 1d-array[float64]
+	Coefs        []float64   `json:"coefs,omitempty"` // 1d-array[float64]
+	Intercept    float64     `json:"intercept,omitempty"`
+	Alpha        float64     `json:"alpha"`
+	FitIntercept bool        `json:"fit_intercept"`
+
+	Metrics metrics.Metrics
+}
+
+func NewRidge(X [][]float64, Y []float64, alpha float64, fitIntercept bool) Ensemble.Estimator {
+	if len(X) == 0 || len(Y) == 0 {
+		panic("X and Y cannot be empty")
+	}
+	if len(X) != len(Y) {
+		panic("X and Y must have the same number of rows")
+	}
+	for i := range X {
+		if len(X[i]) == 0 {
+			panic("X cannot have empty rows")
+		}
+	}
+	if alpha < 0 || math.IsNaN(alpha) {
+		panic("Alpha must be a non-negative number")
+	}
+
+	preAllocX := make([][]float64, len(X))
+	for i := range X {
+		preAllocX[i] = make([]float64, len(X[i]))
+		copy(preAllocX[i], X[i])
+	}
+
+	preAllocY := make([]float64, len(Y))
+	for i, val := range Y {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			panic(fmt.Sprintf("Y contains NaN or Inf at index %d", i))
+		}
+		if val == 0.0 {
+			val = 1e-8 // Avoid Div0
+		}
+		preAllocY[i] = val
+	}
+
+	return &Ridge{
+		X:            preAllocX,
+		Y:            preAllocY,
+		Coefs:        make([]float64, len(X[0])),
+		Alpha:        alpha,
+		FitIntercept: fitIntercept,
+	}
+}
+
+func NewDefaultRidge(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewRidge(X, Y, 1.0, true)
+}
+
+// centerData returns the column means of x and the mean of y along with centered copies.
+// When fitIntercept is false the means are zero and the data is copied as is.
+func centerData(x [][]float64, y []float64, fitIntercept bool) (xMeans []float64, yMean float64, xCentered *mat.Dense, yCentered []float64) {
+	nRows, nCols := len(x), len(x[0])
+	xMeans = make([]float64, nCols)
+	if fitIntercept {
+		for _, row := range x {
+			for j, val := range row {
+				xMeans[j] += val
+			}
+		}
+		for j := range xMeans {
+			xMeans[j] /= float64(nRows)
+		}
+		for _, val := range y {
+			yMean += val
+		}
+		yMean /= float64(nRows)
+	}
+
+	xFlattened := make([]float64, 0, nRows*nCols)
+	for _, row := range x {
+		for j, val := range row {
+			xFlattened = append(xFlattened, val-xMeans[j])
+		}
+	}
+	xCentered = mat.NewDense(nRows, nCols, xFlattened)
+
+	yCentered = make([]float64, nRows)
+	for i, val := range y {
+		yCentered[i] = val - yMean
+	}
+	return
+}
+
+// solveSVD computes the ridge solution V * diag(s / (s^2 + alpha)) * U^T * y from a thin SVD of X.
+// Factorizing once allows solving for many alphas at the cost of a few matrix-vector products.
+func solveSVD(svd *mat.SVD, y []float64, alpha float64) []float64 {
+	var u, v mat.Dense
+	svd.UTo(&u)
+	svd.VTo(&v)
+	singularValues := svd.Values(nil)
+
+	var uty mat.VecDense
+	uty.MulVec(u.T(), mat.NewVecDense(len(y), y))
+
+	eps := 1e-8
+	d := mat.NewVecDense(len(singularValues), nil)
+	for i, s := range singularValues {
+		if s <= eps {
+			continue
+		}
+		d.SetVec(i, s/(s*s+alpha)*uty.AtVec(i))
+	}
+
+	var beta mat.VecDense
+	beta.MulVec(&v, d)
+	return beta.RawVector().Data
+}
+
+func (r *Ridge) Fit() {
+	xMeans, yMean, xCentered, yCentered := centerData(r.X, r.Y, r.FitIntercept)
+
+	var svd mat.SVD
+	ok := svd.Factorize(xCentered, mat.SVDThin)
+	if !ok {
+		panic("SVD Factorization Failed")
+	}
+
+	r.Coefs = solveSVD(&svd, yCentered, r.Alpha)
+	r.Intercept = yMean
+	for j, coef := range r.Coefs {
+		r.Intercept -= coef * xMeans[j]
+	}
+
+	preds := make([]float64, len(r.Y))
+	for i, row := range r.X {
+		preds[i] = r.Predict(row)
+	}
+	r.Metrics = metrics.Evaluate(r.Y, preds)
+}
+
+func (r *Ridge) Predict(x []float64) float64 {
+	if len(x) != len(r.Coefs) {
+		panic("Input feature length does not match number of coefficients")
+	}
+
+	pred := r.Intercept
+	for i, coef := range r.Coefs {
+		pred += coef * x[i]
+	}
+	return pred
+}
+
+func (r *Ridge) GetMetrics() metrics.Metrics {
+	return r.Metrics
+}
@@ -0,0 +1,83 @@
+package Ridge
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// closedForm solves the normal equations of ridge regression directly. With an intercept, a column of ones joins X
+// and is left out of the penalty, which is what centering the data achieves.
+func closedForm(x [][]float64, y []float64, alpha float64, fitIntercept bool) (intercept float64, coefs []float64) {
+	offset := 0
+	if fitIntercept {
+		offset = 1
+	}
+	nCols := len(x[0]) + offset
+	design := mat.NewDense(len(x), nCols, nil)
+	for i, row := range x {
+		if fitIntercept {
+			design.Set(i, 0, 1)
+		}
+		for j, val := range row {
+			design.Set(i, j+offset, val)
+		}
+	}
+
+	var gram mat.Dense
+	gram.Mul(design.T(), design)
+	for j := offset; j < nCols; j++ {
+		gram.Set(j, j, gram.At(j, j)+alpha)
+	}
+	var xty, beta mat.VecDense
+	xty.MulVec(design.T(), mat.NewVecDense(len(y), y))
+	if err := beta.SolveVec(&gram, &xty); err != nil {
+		panic(err)
+	}
+
+	solution := beta.RawVector().Data
+	if fitIntercept {
+		return solution[0], solution[1:]
+	}
+	return 0, solution
+}
+
+func TestRidgeClosedForm(t *testing.T) {
+	x, y := sparseLinear(40, 5, 2)
+	for _, fitIntercept := range []bool{true, false} {
+		for _, alpha := range []float64{0, 0.1, 1, 10, 100} {
+			ridge := NewRidge(x, y, alpha, fitIntercept).(*Ridge)
+			ridge.Fit()
+
+			intercept, coefs := closedForm(x, y, alpha, fitIntercept)
+			if math.Abs(ridge.Intercept-intercept) > 1e-9 {
+				t.Errorf("alpha %v, intercept %v: intercept %v, want %v", alpha, fitIntercept, ridge.Intercept, intercept)
+			}
+			for j, coef := range coefs {
+				if math.Abs(ridge.Coefs[j]-coef) > 1e-9 {
+					t.Errorf("alpha %v, intercept %v: coefficients %v, want %v", alpha, fitIntercept, ridge.Coefs, coefs)
+					break
+				}
+			}
+		}
+	}
+}
+
+// A larger penalty shrinks the coefficients towards zero.
+func TestRidgeShrinks(t *testing.T) {
+	x, y := sparseLinear(40, 5, 2)
+	previous := math.Inf(1)
+	for _, alpha := range []float64{0, 1, 10, 100, 1000} {
+		ridge := NewRidge(x, y, alpha, true).(*Ridge)
+		ridge.Fit()
+		norm := 0.0
+		for _, coef := range ridge.Coefs {
+			norm += coef * coef
+		}
+		if norm >= previous {
+			t.Errorf("alpha %v: squared coefficient norm %v did not shrink from %v", alpha, norm, previous)
+		}
+		previous = norm
+	}
+}
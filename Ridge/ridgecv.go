@@ -0,0 +1,166 @@
+package Ridge
+
+import (
+	"GoML/Ensemble"
+	"GoML/crossval"
+	"GoML/metrics"
+	"math/rand"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+type RidgeCV struct {
+	X            [][]float64 `json:"X,omitempty"`
+	Y            []float64   `json:"y,omitempty"`
+	Alphas       []float64   `json:"alphas"`
+	NFolds       int         `json:"n_folds"`
+	FitIntercept bool        `json:"fit_intercept"`
+
+	// Fit results
+	BestAlpha float64              `json:"best_alpha"`
+	Coefs     []float64            `json:"coefs,omitempty"`
+	Intercept float64              `json:"intercept,omitempty"`
+	Path      []crossval.PathPoint `json:"path"`
+
+	Metrics metrics.Metrics
+
+	RandSeed *int64 `json:"random_seed"`
+	rng      *rand.Rand
+}
+
+func NewRidgeCV(X [][]float64, Y []float64, nAlphas, nFolds int, fitIntercept bool, randSeed *int64) Ensemble.Estimator {
+	if nAlphas <= 0 {
+		panic("nAlphas must be positive")
+	}
+	if nFolds < 2 || nFolds > len(Y) {
+		panic("nFolds must be in [2, nRows]")
+	}
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+
+	// Reuse Ridge's validation and copying of the data
+	base := NewRidge(X, Y, 0, fitIntercept).(*Ridge)
+
+	return &RidgeCV{
+		X:            base.X,
+		Y:            base.Y,
+		Alphas:       crossval.LogSpace(1e3, 1e-3, nAlphas),
+		NFolds:       nFolds,
+		FitIntercept: fitIntercept,
+		Coefs:        base.Coefs,
+		RandSeed:     randSeed,
+		rng:          rand.New(rand.NewSource(*randSeed)),
+	}
+}
+
+func NewDefaultRidgeCV(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewRidgeCV(X, Y, 50, 5, true, nil)
+}
+
+// fitPath fits every alpha on (x, y) from a single SVD and returns the coefficients and intercepts.
+func fitPath(x [][]float64, y []float64, alphas []float64, fitIntercept bool) (coefs [][]float64, intercepts []float64) {
+	xMeans, yMean, xCentered, yCentered := centerData(x, y, fitIntercept)
+
+	var svd mat.SVD
+	ok := svd.Factorize(xCentered, mat.SVDThin)
+	if !ok {
+		panic("SVD Factorization Failed")
+	}
+
+	coefs = make([][]float64, len(alphas))
+	intercepts = make([]float64, len(alphas))
+	for a, alpha := range alphas {
+		coefs[a] = solveSVD(&svd, yCentered, alpha)
+		intercepts[a] = yMean
+		for j, coef := range coefs[a] {
+			intercepts[a] -= coef * xMeans[j]
+		}
+	}
+	return
+}
+
+func (cv *RidgeCV) Fit() {
+	folds := crossval.KFold(len(cv.Y), cv.NFolds, cv.rng)
+
+	foldMSE := make([][]float64, len(cv.Alphas))
+	for a := range foldMSE {
+		foldMSE[a] = make([]float64, len(folds))
+	}
+
+	for f, fold := range folds {
+		trainX := make([][]float64, len(fold.Train))
+		trainY := make([]float64, len(fold.Train))
+		for i, idx := range fold.Train {
+			trainX[i] = cv.X[idx]
+			trainY[i] = cv.Y[idx]
+		}
+
+		coefs, intercepts := fitPath(trainX, trainY, cv.Alphas, cv.FitIntercept)
+		for a := range cv.Alphas {
+			sse := 0.0
+			for _, idx := range fold.Test {
+				pred := intercepts[a]
+				for j, coef := range coefs[a] {
+					pred += coef * cv.X[idx][j]
+				}
+				sse += (pred - cv.Y[idx]) * (pred - cv.Y[idx])
+			}
+			foldMSE[a][f] = sse / float64(len(fold.Test))
+		}
+	}
+
+	coefs, intercepts := fitPath(cv.X, cv.Y, cv.Alphas, cv.FitIntercept)
+
+	cv.Path = make([]crossval.PathPoint, len(cv.Alphas))
+	best := 0
+	for a, alpha := range cv.Alphas {
+		mean, std := stat.MeanStdDev(foldMSE[a], nil)
+		nonZero := 0
+		for _, coef := range coefs[a] {
+			if coef != 0 {
+				nonZero++
+			}
+		}
+		cv.Path[a] = crossval.PathPoint{
+			Alpha:         alpha,
+			Coefs:         coefs[a],
+			Intercept:     intercepts[a],
+			NonZero:       nonZero,
+			ValidationMSE: mean,
+			ValidationStd: std,
+		}
+		if mean < cv.Path[best].ValidationMSE {
+			best = a
+		}
+	}
+
+	cv.BestAlpha = cv.Alphas[best]
+	cv.Coefs = coefs[best]
+	cv.Intercept = intercepts[best]
+
+	preds := make([]float64, len(cv.Y))
+	for i, row := range cv.X {
+		preds[i] = cv.Predict(row)
+	}
+	cv.Metrics = metrics.Evaluate(cv.Y, preds)
+}
+
+func (cv *RidgeCV) Predict(x []float64) float64 {
+	if len(x) != len(cv.Coefs) {
+		panic("Input feature length does not match number of coefficients")
+	}
+
+	pred := cv.Intercept
+	for i, coef := range cv.Coefs {
+		pred += coef * x[i]
+	}
+	return pred
+}
+
+func (cv *RidgeCV) GetMetrics() metrics.Metrics {
+	return cv.Metrics
+}
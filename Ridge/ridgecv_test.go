@@ -0,0 +1,74 @@
+package Ridge
+
+import (
+	"GoML/crossval"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// sparseLinear draws y = 3 x0 - 2 x1 + x2 + noise over nFeatures standard normal features.
+func sparseLinear(nRows, nFeatures int, seed int64) ([][]float64, []float64) {
+	rng := rand.New(rand.NewSource(seed))
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = make([]float64, nFeatures)
+		for j := range x[i] {
+			x[i][j] = rng.NormFloat64()
+		}
+		y[i] = 5 + 3*x[i][0] - 2*x[i][1] + x[i][2] + 2*rng.NormFloat64()
+	}
+	return x, y
+}
+
+func TestRidgeCVBestAlpha(t *testing.T) {
+	// Few rows for many features, so that the best alpha lies inside the grid
+	x, y := sparseLinear(30, 20, 1)
+	seed := int64(1)
+	cv := NewRidgeCV(x, y, 20, 5, true, &seed).(*RidgeCV)
+	cv.Fit()
+
+	best := 0
+	for a, point := range cv.Path {
+		if point.Alpha != cv.Alphas[a] {
+			t.Fatalf("path point %d has alpha %v, want %v", a, point.Alpha, cv.Alphas[a])
+		}
+		if point.ValidationMSE < cv.Path[best].ValidationMSE {
+			best = a
+		}
+	}
+	if cv.BestAlpha != cv.Path[best].Alpha || cv.Intercept != cv.Path[best].Intercept {
+		t.Errorf("best alpha %v, want %v of the least validation MSE", cv.BestAlpha, cv.Path[best].Alpha)
+	}
+	for j, coef := range cv.Coefs {
+		if coef != cv.Path[best].Coefs[j] {
+			t.Errorf("coefficients %v, want those of the best path point %v", cv.Coefs, cv.Path[best].Coefs)
+			break
+		}
+	}
+	if best == 0 || best == len(cv.Path)-1 {
+		t.Errorf("best alpha %v at the edge of the grid", cv.BestAlpha)
+	}
+
+	// Every validation error is the mean over the same folds of a Ridge fitted without the fold
+	folds := crossval.KFold(len(y), 5, rand.New(rand.NewSource(seed)))
+	for _, point := range cv.Path {
+		mse := 0.0
+		for _, fold := range folds {
+			trainX := make([][]float64, len(fold.Train))
+			trainY := make([]float64, len(fold.Train))
+			for i, idx := range fold.Train {
+				trainX[i], trainY[i] = x[idx], y[idx]
+			}
+			ridge := NewRidge(trainX, trainY, point.Alpha, true).(*Ridge)
+			ridge.Fit()
+			for _, idx := range fold.Test {
+				mse += math.Pow(ridge.Predict(x[idx])-y[idx], 2) / float64(len(fold.Test)) / float64(len(folds))
+			}
+		}
+		if math.Abs(point.ValidationMSE-mse) > 1e-9*mse {
+			t.Errorf("alpha %v: validation MSE %v, want %v", point.Alpha, point.ValidationMSE, mse)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package Robust
+
+import (
+	"GoML/Ensemble"
+	"GoML/OLS"
+	"GoML/metrics"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// median returns the median of values without modifying them.
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// mad returns the median absolute deviation of values around their median.
+func mad(values []float64) float64 {
+	med := median(values)
+	deviations := make([]float64, len(values))
+	for i, val := range values {
+		deviations[i] = math.Abs(val - med)
+	}
+	return median(deviations)
+}
+
+// robustScale is the MAD rescaled to be a consistent estimator of the standard deviation under normality.
+func robustScale(resid []float64) float64 {
+	return mad(resid) / 0.6745
+}
+
+func copyData(X [][]float64, Y []float64) ([][]float64, []float64) {
+	if len(X) == 0 || len(Y) == 0 {
+		panic("X and Y cannot be empty")
+	}
+	if len(X) != len(Y) {
+		panic("X and Y must have the same number of rows")
+	}
+
+	preAllocX := make([][]float64, len(X))
+	for i := range X {
+		if len(X[i]) == 0 {
+			panic("X cannot have empty rows")
+		}
+		preAllocX[i] = make([]float64, len(X[i]))
+		copy(preAllocX[i], X[i])
+	}
+
+	preAllocY := make([]float64, len(Y))
+	for i, val := range Y {
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			panic(fmt.Sprintf("Y contains NaN or Inf at index %d", i))
+		}
+		if val == 0.0 {
+			val = 1e-8 // Avoid Div0
+		}
+		preAllocY[i] = val
+	}
+	return preAllocX, preAllocY
+}
+
+// Huber is a linear regressor with an intercept minimizing the Huber loss: squared for residuals within
+// Epsilon robust standard deviations and linear beyond. It is fitted with iteratively reweighted least squares.
+type Huber struct {
+	X         [][]float64 `json:"X,omitempty"`
+	Y         []float64   `json:"y,omitempty"`
+	Coefs     []float64   `json:"coefs,omitempty"`
+	Intercept float64     `json:"intercept,omitempty"`
+	Epsilon   float64     `json:"epsilon"`
+	MaxIter   int         `json:"max_iter"`
+	Tol       float64     `json:"tol"`
+
+	// Fit results
+	Weights     []float64 `json:"weights"`      // Final IRLS weights, 1 for inliers and < 1 for down-weighted rows
+	OutlierMask []bool    `json:"outlier_mask"` // Rows whose residual exceeds Epsilon * Scale
+	Scale       float64   `json:"scale"`        // Robust residual standard deviation (MAD / 0.6745)
+	NIter       int       `json:"n_iter"`
+	Converged   bool      `json:"converged"`
+
+	Metrics metrics.Metrics
+}
+
+func NewHuber(X [][]float64, Y []float64, epsilon float64, maxIter int, tol float64) Ensemble.Estimator {
+	if epsilon < 1 {
+		panic("Epsilon must be >= 1")
+	}
+	if maxIter <= 0 {
+		panic("MaxIter must be positive")
+	}
+	preAllocX, preAllocY := copyData(X, Y)
+
+	return &Huber{
+		X:       preAllocX,
+		Y:       preAllocY,
+		Coefs:   make([]float64, len(X[0])),
+		Epsilon: epsilon,
+		MaxIter: maxIter,
+		Tol:     tol,
+	}
+}
+
+func NewDefaultHuber(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewHuber(X, Y, 1.35, 100, 1e-5)
+}
+
+func (h *Huber) residuals() []float64 {
+	resid := make([]float64, len(h.Y))
+	for i, row := range h.X {
+		resid[i] = h.Y[i] - h.Predict(row)
+	}
+	return resid
+}
+
+func (h *Huber) Fit() {
+	nRows := len(h.Y)
+	h.Weights = make([]float64, nRows)
+	for i := range h.Weights {
+		h.Weights[i] = 1.0
+	}
+
+	h.Converged = false
+	for iter := 1; iter <= h.MaxIter; iter++ {
+		h.NIter = iter
+		model := OLS.NewWLS(h.X, h.Y, h.Weights).(*OLS.OLS)
+		model.Fit()
+
+		maxDelta := math.Abs(model.Intercept - h.Intercept)
+		for j, coef := range model.Coefs {
+			maxDelta = math.Max(maxDelta, math.Abs(coef-h.Coefs[j]))
+		}
+		h.Intercept = model.Intercept
+		h.Coefs = slices.Clone(model.Coefs)
+
+		resid := h.residuals()
+		h.Scale = robustScale(resid)
+		if h.Scale == 0 {
+			// At least half of the rows are fitted exactly, nothing left to down-weight
+			h.Converged = true
+			break
+		}
+		for i, r := range resid {
+			u := math.Abs(r) / h.Scale
+			if u <= h.Epsilon {
+				h.Weights[i] = 1.0
+			} else {
+				h.Weights[i] = h.Epsilon / u
+			}
+		}
+
+		if iter > 1 && maxDelta < h.Tol {
+			h.Converged = true
+			break
+		}
+	}
+
+	resid := h.residuals()
+	h.OutlierMask = make([]bool, nRows)
+	for i, r := range resid {
+		h.OutlierMask[i] = h.Scale > 0 && math.Abs(r) > h.Epsilon*h.Scale
+	}
+
+	preds := make([]float64, nRows)
+	for i, row := range h.X {
+		preds[i] = h.Predict(row)
+	}
+	h.Metrics = metrics.Evaluate(h.Y, preds)
+}
+
+func (h *Huber) Predict(x []float64) float64 {
+	if len(x) != len(h.Coefs) {
+		panic("Input feature length does not match number of coefficients")
+	}
+
+	pred := h.Intercept
+	for i, coef := range h.Coefs {
+		pred += coef * x[i]
+	}
+	return pred
+}
+
+func (h *Huber) GetMetrics() metrics.Metrics {
+	return h.Metrics
+}
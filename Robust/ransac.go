@@ -0,0 +1,147 @@
+package Robust
+
+import (
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RANSAC repeatedly fits the base estimator on random minimal subsets, keeps the subset whose fit agrees with the
+// most rows (absolute residual within ResidualThreshold) and refits the base estimator on that consensus set.
+type RANSAC struct {
+	X [][]float64
+	Y []float64
+
+	Factory           func(x [][]float64, y []float64) Ensemble.Estimator
+	MinSamples        int
+	ResidualThreshold float64
+	MaxTrials         int
+
+	// Fit results
+	Estimator  Ensemble.Estimator // Base estimator refitted on the inliers
+	InlierMask []bool
+	NInliers   int
+	NTrials    int
+
+	Metrics metrics.Metrics // Evaluated on all rows, outliers included
+
+	RandSeed *int64
+	rng      *rand.Rand
+}
+
+// NewRANSAC creates a RANSAC regressor. A minSamples of 0 uses nFeatures + 1 rows per trial and a
+// residualThreshold of 0 uses the median absolute deviation of Y.
+func NewRANSAC(estimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator, x [][]float64, y []float64, minSamples int, residualThreshold float64, maxTrials int, randSeed *int64) Ensemble.Estimator {
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+	preAllocX, preAllocY := copyData(x, y)
+
+	if minSamples <= 0 {
+		minSamples = len(x[0]) + 1
+	}
+	if minSamples > len(y) {
+		panic("MinSamples cannot exceed the number of rows")
+	}
+	if residualThreshold <= 0 {
+		residualThreshold = mad(preAllocY)
+	}
+	if maxTrials <= 0 {
+		panic("MaxTrials must be positive")
+	}
+
+	return &RANSAC{
+		X:                 preAllocX,
+		Y:                 preAllocY,
+		Factory:           estimatorFactory,
+		MinSamples:        minSamples,
+		ResidualThreshold: residualThreshold,
+		MaxTrials:         maxTrials,
+		RandSeed:          randSeed,
+		rng:               rand.New(rand.NewSource(*randSeed)),
+	}
+}
+
+func NewDefaultRANSAC(estimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator, x [][]float64, y []float64) Ensemble.Estimator {
+	return NewRANSAC(estimatorFactory, x, y, 0, 0, 100, nil)
+}
+
+func (rs *RANSAC) subset(indices []int) ([][]float64, []float64) {
+	x := make([][]float64, len(indices))
+	y := make([]float64, len(indices))
+	for i, idx := range indices {
+		x[i] = rs.X[idx]
+		y[i] = rs.Y[idx]
+	}
+	return x, y
+}
+
+func (rs *RANSAC) Fit() {
+	nRows := len(rs.Y)
+
+	var bestMask []bool
+	bestInliers := -1
+	bestSSR := math.Inf(1)
+
+	for trial := 0; trial < rs.MaxTrials; trial++ {
+		rs.NTrials = trial + 1
+		sample := rs.rng.Perm(nRows)[:rs.MinSamples]
+		sx, sy := rs.subset(sample)
+
+		estimator := rs.Factory(sx, sy)
+		estimator.Fit()
+
+		mask := make([]bool, nRows)
+		nInliers := 0
+		SSR := 0.0
+		for i, row := range rs.X {
+			r := rs.Y[i] - estimator.Predict(row)
+			if math.Abs(r) <= rs.ResidualThreshold {
+				mask[i] = true
+				nInliers++
+				SSR += r * r
+			}
+		}
+
+		if nInliers > bestInliers || (nInliers == bestInliers && SSR < bestSSR) {
+			bestMask, bestInliers, bestSSR = mask, nInliers, SSR
+		}
+		if bestInliers == nRows {
+			break
+		}
+	}
+
+	if bestInliers < rs.MinSamples {
+		panic("RANSAC could not find a consensus set larger than MinSamples, increase ResidualThreshold")
+	}
+
+	inliers := make([]int, 0, bestInliers)
+	for i, isInlier := range bestMask {
+		if isInlier {
+			inliers = append(inliers, i)
+		}
+	}
+	ix, iy := rs.subset(inliers)
+	rs.Estimator = rs.Factory(ix, iy)
+	rs.Estimator.Fit()
+
+	rs.InlierMask = bestMask
+	rs.NInliers = bestInliers
+
+	preds := make([]float64, nRows)
+	for i, row := range rs.X {
+		preds[i] = rs.Predict(row)
+	}
+	rs.Metrics = metrics.Evaluate(rs.Y, preds)
+}
+
+func (rs *RANSAC) Predict(x []float64) float64 {
+	return rs.Estimator.Predict(x)
+}
+
+func (rs *RANSAC) GetMetrics() metrics.Metrics {
+	return rs.Metrics
+}
@@ -0,0 +1,114 @@
+package Robust
+
+import (
+	"GoML/OLS"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var cleanParams = []float64{3, 2, -1} // Intercept, then coefficients
+
+// contaminated draws y = 3 + 2 x1 - x2 + small noise and shifts every seventh row up by 50, so that the outliers all
+// pull a least squares fit the same way.
+func contaminated() (x [][]float64, y []float64, outliers []bool) {
+	rng := rand.New(rand.NewSource(1))
+	x = make([][]float64, 100)
+	y = make([]float64, len(x))
+	outliers = make([]bool, len(x))
+	for i := range x {
+		x[i] = []float64{10 * rng.Float64(), 10 * rng.Float64()}
+		y[i] = cleanParams[0] + cleanParams[1]*x[i][0] + cleanParams[2]*x[i][1] + 0.1*rng.NormFloat64()
+		if i%7 == 0 {
+			y[i] += 50
+			outliers[i] = true
+		}
+	}
+	return x, y, outliers
+}
+
+// maxParamError is the largest absolute difference between the fitted and the clean parameters.
+func maxParamError(intercept float64, coefs []float64) float64 {
+	worst := math.Abs(intercept - cleanParams[0])
+	for j, coef := range coefs {
+		worst = math.Max(worst, math.Abs(coef-cleanParams[j+1]))
+	}
+	return worst
+}
+
+func TestRecoverCleanCoefficients(t *testing.T) {
+	x, y, outliers := contaminated()
+
+	ols := OLS.NewOLS(x, y).(*OLS.OLS)
+	ols.Fit()
+	if err := maxParamError(ols.Intercept, ols.Coefs); err < 1 {
+		t.Fatalf("OLS is within %v of the clean parameters, the outliers should bias it", err)
+	}
+
+	huber := NewDefaultHuber(x, y).(*Huber)
+	huber.Fit()
+	if err := maxParamError(huber.Intercept, huber.Coefs); err > 0.1 {
+		t.Errorf("Huber parameters %v %v are %v off the clean ones", huber.Intercept, huber.Coefs, err)
+	}
+	// Beyond Epsilon = 1.35 scales the mask also holds the noisiest clean rows, but every planted outlier
+	for i, outlier := range outliers {
+		if outlier && !huber.OutlierMask[i] {
+			t.Errorf("Huber did not flag planted outlier %d", i)
+		}
+	}
+
+	seed := int64(1)
+	theilSen := NewTheilSen(x, y, 0, 10000, 300, 1e-6, &seed).(*TheilSen)
+	theilSen.Fit()
+	if err := maxParamError(theilSen.Intercept, theilSen.Coefs); err > 0.1 {
+		t.Errorf("Theil-Sen parameters %v %v are %v off the clean ones", theilSen.Intercept, theilSen.Coefs, err)
+	}
+}
+
+func TestRANSACInlierMask(t *testing.T) {
+	x, y, outliers := contaminated()
+	seed := int64(1)
+	ransac := NewRANSAC(OLS.NewOLS, x, y, 0, 1, 100, &seed).(*RANSAC)
+	ransac.Fit()
+
+	for i, inlier := range ransac.InlierMask {
+		if inlier == outliers[i] {
+			t.Errorf("row %d: inlier %v, planted outlier %v", i, inlier, outliers[i])
+		}
+	}
+	if ransac.NInliers != len(y)-15 {
+		t.Errorf("%d inliers, want %d", ransac.NInliers, len(y)-15)
+	}
+	ols := ransac.Estimator.(*OLS.OLS)
+	if err := maxParamError(ols.Intercept, ols.Coefs); err > 0.1 {
+		t.Errorf("refitted parameters %v %v are %v off the clean ones", ols.Intercept, ols.Coefs, err)
+	}
+}
+
+func TestRandSeedDeterminism(t *testing.T) {
+	x, y, _ := contaminated()
+	fitTheilSen := func(seed int64) *TheilSen {
+		ts := NewTheilSen(x, y, 4, 500, 300, 1e-6, &seed).(*TheilSen)
+		ts.Fit()
+		return ts
+	}
+	fitRANSAC := func(seed int64) *RANSAC {
+		rs := NewRANSAC(OLS.NewOLS, x, y, 0, 1, 20, &seed).(*RANSAC)
+		rs.Fit()
+		return rs
+	}
+
+	a, b := fitTheilSen(7), fitTheilSen(7)
+	if a.Intercept != b.Intercept || !slices.Equal(a.Coefs, b.Coefs) {
+		t.Errorf("Theil-Sen with the same seed fitted %v %v and %v %v", a.Intercept, a.Coefs, b.Intercept, b.Coefs)
+	}
+	if c := fitTheilSen(8); c.Intercept == a.Intercept {
+		t.Errorf("Theil-Sen with another seed fitted the same intercept %v, subsets are not sampled", c.Intercept)
+	}
+
+	r1, r2 := fitRANSAC(7), fitRANSAC(7)
+	if r1.NTrials != r2.NTrials || !slices.Equal(r1.InlierMask, r2.InlierMask) || r1.Predict(x[0]) != r2.Predict(x[0]) {
+		t.Errorf("RANSAC with the same seed gave different fits")
+	}
+}
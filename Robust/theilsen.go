@@ -0,0 +1,239 @@
+package Robust
+
+import (
+	"GoML/Ensemble"
+	"GoML/metrics"
+	"math"
+	"math/rand"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// TheilSen is a linear regressor with an intercept that solves least squares on many small subsets of rows and
+// takes the spatial median of the resulting parameter vectors. With NSubsamples = nFeatures + 1 it tolerates
+// roughly 29% of arbitrarily corrupted rows for simple regression.
+type TheilSen struct {
+	X         [][]float64 `json:"X,omitempty"`
+	Y         []float64   `json:"y,omitempty"`
+	Coefs     []float64   `json:"coefs,omitempty"`
+	Intercept float64     `json:"intercept,omitempty"`
+
+	NSubsamples      int     `json:"n_subsamples"`      // Rows per subset
+	MaxSubpopulation int     `json:"max_subpopulation"` // Subsets are sampled at random beyond this many combinations
+	MaxIter          int     `json:"max_iter"`          // Spatial median iterations
+	Tol              float64 `json:"tol"`
+
+	// Fit results
+	NSubsets    int     `json:"n_subsets"`
+	Scale       float64 `json:"scale"`        // Robust residual standard deviation (MAD / 0.6745)
+	OutlierMask []bool  `json:"outlier_mask"` // Rows whose residual exceeds 2.5 * Scale
+
+	Metrics metrics.Metrics
+
+	RandSeed *int64 `json:"random_seed"`
+	rng      *rand.Rand
+}
+
+// NewTheilSen creates a Theil-Sen regressor. An nSubsamples of 0 uses nFeatures + 1 rows per subset.
+func NewTheilSen(X [][]float64, Y []float64, nSubsamples, maxSubpopulation, maxIter int, tol float64, randSeed *int64) Ensemble.Estimator {
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+	preAllocX, preAllocY := copyData(X, Y)
+
+	nParams := len(X[0]) + 1
+	if nSubsamples <= 0 {
+		nSubsamples = nParams
+	}
+	if nSubsamples < nParams || nSubsamples > len(Y) {
+		panic("NSubsamples must be in [nFeatures + 1, nRows]")
+	}
+	if maxSubpopulation <= 0 || maxIter <= 0 {
+		panic("MaxSubpopulation and MaxIter must be positive")
+	}
+
+	return &TheilSen{
+		X:                preAllocX,
+		Y:                preAllocY,
+		Coefs:            make([]float64, len(X[0])),
+		NSubsamples:      nSubsamples,
+		MaxSubpopulation: maxSubpopulation,
+		MaxIter:          maxIter,
+		Tol:              tol,
+		RandSeed:         randSeed,
+		rng:              rand.New(rand.NewSource(*randSeed)),
+	}
+}
+
+func NewDefaultTheilSen(X [][]float64, Y []float64) Ensemble.Estimator {
+	return NewTheilSen(X, Y, 0, 10000, 300, 1e-3, nil)
+}
+
+// binomial returns n choose k, saturating at limit+1 to avoid overflow.
+func binomial(n, k, limit int) int {
+	if k > n-k {
+		k = n - k
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+		if result > limit {
+			return limit + 1
+		}
+	}
+	return result
+}
+
+// subsets lists every k-combination of [0, n) when there are at most limit of them, otherwise limit random ones.
+func (ts *TheilSen) subsets(n, k, limit int) [][]int {
+	if binomial(n, k, limit) > limit {
+		out := make([][]int, limit)
+		for s := range out {
+			out[s] = ts.rng.Perm(n)[:k]
+		}
+		return out
+	}
+
+	out := make([][]int, 0)
+	combination := make([]int, k)
+	for i := range combination {
+		combination[i] = i
+	}
+	for {
+		out = append(out, append([]int(nil), combination...))
+
+		i := k - 1
+		for i >= 0 && combination[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return out
+		}
+		combination[i]++
+		for j := i + 1; j < k; j++ {
+			combination[j] = combination[j-1] + 1
+		}
+	}
+}
+
+// lstsq solves [1 X] * params = y for the given rows in the least squares sense.
+func (ts *TheilSen) lstsq(rows []int) []float64 {
+	nCols := len(ts.X[0]) + 1
+	design := mat.NewDense(len(rows), nCols, nil)
+	target := mat.NewVecDense(len(rows), nil)
+	for i, idx := range rows {
+		design.Set(i, 0, 1.0)
+		for j, val := range ts.X[idx] {
+			design.Set(i, j+1, val)
+		}
+		target.SetVec(i, ts.Y[idx])
+	}
+
+	var svd mat.SVD
+	ok := svd.Factorize(design, mat.SVDThin)
+	if !ok {
+		panic("SVD Factorization Failed")
+	}
+	rank := 0
+	for _, s := range svd.Values(nil) {
+		if s > 1e-8 {
+			rank++
+		}
+	}
+
+	var beta mat.Dense
+	svd.SolveTo(&beta, target, rank)
+	return beta.RawMatrix().Data
+}
+
+// spatialMedian finds the point minimizing the sum of euclidean distances to points with Weiszfeld's algorithm.
+func spatialMedian(points [][]float64, maxIter int, tol float64) []float64 {
+	dim := len(points[0])
+	current := make([]float64, dim)
+	for _, p := range points {
+		for j, val := range p {
+			current[j] += val / float64(len(points))
+		}
+	}
+
+	for iter := 0; iter < maxIter; iter++ {
+		next := make([]float64, dim)
+		sumInvDist := 0.0
+		for _, p := range points {
+			dist := 0.0
+			for j, val := range p {
+				dist += (val - current[j]) * (val - current[j])
+			}
+			dist = math.Sqrt(dist)
+			if dist < 1e-12 {
+				// The current estimate sits on a point, skip it to keep the update defined
+				continue
+			}
+			for j, val := range p {
+				next[j] += val / dist
+			}
+			sumInvDist += 1 / dist
+		}
+		if sumInvDist == 0 {
+			return current
+		}
+
+		shift := 0.0
+		for j := range next {
+			next[j] /= sumInvDist
+			shift += (next[j] - current[j]) * (next[j] - current[j])
+		}
+		current = next
+		if math.Sqrt(shift) < tol {
+			break
+		}
+	}
+	return current
+}
+
+func (ts *TheilSen) Fit() {
+	nRows := len(ts.Y)
+
+	subsets := ts.subsets(nRows, ts.NSubsamples, ts.MaxSubpopulation)
+	params := make([][]float64, len(subsets))
+	for s, rows := range subsets {
+		params[s] = ts.lstsq(rows)
+	}
+	ts.NSubsets = len(subsets)
+
+	median := spatialMedian(params, ts.MaxIter, ts.Tol)
+	ts.Intercept = median[0]
+	ts.Coefs = median[1:]
+
+	preds := make([]float64, nRows)
+	resid := make([]float64, nRows)
+	for i, row := range ts.X {
+		preds[i] = ts.Predict(row)
+		resid[i] = ts.Y[i] - preds[i]
+	}
+
+	ts.Scale = robustScale(resid)
+	ts.OutlierMask = make([]bool, nRows)
+	for i, r := range resid {
+		ts.OutlierMask[i] = ts.Scale > 0 && math.Abs(r) > 2.5*ts.Scale
+	}
+	ts.Metrics = metrics.Evaluate(ts.Y, preds)
+}
+
+func (ts *TheilSen) Predict(x []float64) float64 {
+	if len(x) != len(ts.Coefs) {
+		panic("Input feature length does not match number of coefficients")
+	}
+
+	pred := ts.Intercept
+	for i, coef := range ts.Coefs {
+		pred += coef * x[i]
+	}
+	return pred
+}
+
+func (ts *TheilSen) GetMetrics() metrics.Metrics {
+	return ts.Metrics
+}
@@ -0,0 +1,229 @@
+package conformal
+
+import (
+	"GoML/Ensemble"
+	"GoML/crossval"
+	"GoML/metrics"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// Calibration methods
+const (
+	Split  = "split" // Calibrate on held-out rows
+	CVPlus = "cv+"   // Calibrate on out-of-fold residuals
+)
+
+// minDifficulty keeps the scale of a normalized interval positive when the difficulty model predicts none.
+const minDifficulty = 1e-8
+
+// Conformal wraps any estimator factory to predict intervals containing the target with probability at least
+// 1 - Alpha, for any distribution of the data as long as the rows are exchangeable.
+//
+// Split calibrates the absolute residuals of an estimator on CalibrationFraction of the rows it was not fitted on.
+// CVPlus (Barber et al., 2021) fits one estimator per fold and combines the out-of-fold residuals of every row with
+// the prediction at x of the estimator that did not see it, which uses every row for both fitting and calibration
+// at the cost of NFolds fits and a coverage guarantee of 1 - 2 * Alpha (close to 1 - Alpha in practice).
+//
+// Setting DifficultyFactory normalizes the residuals by a second model fitted to the absolute training residuals of
+// the estimator, so intervals are wider where the estimator is expected to err more. The difficulty model should
+// predict positive values and works best with estimators that do not interpolate their training rows.
+type Conformal struct {
+	X [][]float64
+	Y []float64
+
+	Factory             func(x [][]float64, y []float64) Ensemble.Estimator
+	DifficultyFactory   func(x [][]float64, y []float64) Ensemble.Estimator // Nil for constant width intervals
+	Method              string
+	Alpha               float64 // Miscoverage, applied when predicting so it can change after Fit
+	CalibrationFraction float64 // Split only
+	NFolds              int     // CVPlus only
+
+	// Fit results
+	Estimator Ensemble.Estimator // Predicts the point estimate, fitted on the training rows (Split) or every row (CVPlus)
+	Scores    []float64          // Nonconformity score of every calibration row, its normalized absolute residual
+
+	Metrics metrics.Metrics // Metrics of Estimator on all rows
+
+	models  []model // The estimator of Split, or the estimator of every fold of CVPlus
+	rowFold []int   // Fold of every score, CVPlus only
+
+	RandSeed *int64
+	rng      *rand.Rand
+}
+
+// model is an estimator with its optional difficulty model.
+type model struct {
+	estimator  Ensemble.Estimator
+	difficulty Ensemble.Estimator
+}
+
+// scale returns the expected difficulty of x, 1 without a difficulty model.
+func (m model) scale(x []float64) float64 {
+	if m.difficulty == nil {
+		return 1
+	}
+	return math.Max(m.difficulty.Predict(x), minDifficulty)
+}
+
+func newConformal(estimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator, x [][]float64, y []float64, method string, alpha float64, randSeed *int64) *Conformal {
+	if len(x) == 0 || len(x) != len(y) {
+		panic("X and Y must be non-empty with the same number of rows")
+	}
+	if alpha <= 0 || alpha >= 1 {
+		panic("Alpha must be in (0, 1)")
+	}
+	if randSeed == nil {
+		tNow := time.Now().UnixNano()
+		randSeed = &tNow
+	}
+
+	return &Conformal{
+		X:        x,
+		Y:        y,
+		Factory:  estimatorFactory,
+		Method:   method,
+		Alpha:    alpha,
+		RandSeed: randSeed,
+		rng:      rand.New(rand.NewSource(*randSeed)),
+	}
+}
+
+// NewSplitConformal creates a split conformal regressor holding out a calibrationFraction of the rows.
+func NewSplitConformal(estimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator, x [][]float64, y []float64, alpha, calibrationFraction float64, randSeed *int64) Ensemble.Estimator {
+	if calibrationFraction <= 0 || calibrationFraction >= 1 {
+		panic("CalibrationFraction must be in (0, 1)")
+	}
+	c := newConformal(estimatorFactory, x, y, Split, alpha, randSeed)
+	c.CalibrationFraction = calibrationFraction
+	return c
+}
+
+// NewCVPlusConformal creates a CV+ conformal regressor calibrated with nFolds folds.
+func NewCVPlusConformal(estimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator, x [][]float64, y []float64, alpha float64, nFolds int, randSeed *int64) Ensemble.Estimator {
+	if nFolds < 2 || nFolds > len(y) {
+		panic("NFolds must be in [2, nRows]")
+	}
+	c := newConformal(estimatorFactory, x, y, CVPlus, alpha, randSeed)
+	c.NFolds = nFolds
+	return c
+}
+
+// NewDefaultConformal creates a split conformal regressor predicting 90% intervals, calibrated on a quarter of the rows.
+func NewDefaultConformal(estimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator, x [][]float64, y []float64) Ensemble.Estimator {
+	return NewSplitConformal(estimatorFactory, x, y, 0.1, 0.25, nil)
+}
+
+func (c *Conformal) subset(rows []int) ([][]float64, []float64) {
+	x := make([][]float64, len(rows))
+	y := make([]float64, len(rows))
+	for i, row := range rows {
+		x[i] = c.X[row]
+		y[i] = c.Y[row]
+	}
+	return x, y
+}
+
+// fitModel fits the estimator, and the difficulty model on its absolute residuals, to the given rows.
+func (c *Conformal) fitModel(rows []int) model {
+	x, y := c.subset(rows)
+	m := model{estimator: c.Factory(x, y)}
+	m.estimator.Fit()
+	if c.DifficultyFactory != nil {
+		residuals := make([]float64, len(y))
+		for i, row := range x {
+			residuals[i] = math.Abs(y[i] - m.estimator.Predict(row))
+		}
+		m.difficulty = c.DifficultyFactory(x, residuals)
+		m.difficulty.Fit()
+	}
+	return m
+}
+
+// score is the nonconformity score of row under m.
+func (c *Conformal) score(m model, row int) float64 {
+	return math.Abs(c.Y[row]-m.estimator.Predict(c.X[row])) / m.scale(c.X[row])
+}
+
+func (c *Conformal) Fit() {
+	nRows := len(c.Y)
+	switch c.Method {
+	case Split:
+		perm := c.rng.Perm(nRows)
+		nCalibration := min(max(1, int(c.CalibrationFraction*float64(nRows))), nRows-1)
+		calibration, train := perm[:nCalibration], perm[nCalibration:]
+		slices.Sort(calibration)
+		slices.Sort(train)
+
+		m := c.fitModel(train)
+		c.models = []model{m}
+		c.Estimator = m.estimator
+		c.Scores = make([]float64, len(calibration))
+		for i, row := range calibration {
+			c.Scores[i] = c.score(m, row)
+		}
+	case CVPlus:
+		c.models = make([]model, c.NFolds)
+		c.Scores = make([]float64, nRows)
+		c.rowFold = make([]int, nRows)
+		for k, fold := range crossval.KFold(nRows, c.NFolds, c.rng) {
+			c.models[k] = c.fitModel(fold.Train)
+			for _, row := range fold.Test {
+				c.Scores[row] = c.score(c.models[k], row)
+				c.rowFold[row] = k
+			}
+		}
+		c.Estimator = c.Factory(c.X, c.Y)
+		c.Estimator.Fit()
+	default:
+		panic("Method must be split or cv+")
+	}
+
+	preds := make([]float64, nRows)
+	for i, row := range c.X {
+		preds[i] = c.Predict(row)
+	}
+	c.Metrics = metrics.Evaluate(c.Y, preds)
+}
+
+func (c *Conformal) Predict(x []float64) float64 {
+	return c.Estimator.Predict(x)
+}
+
+// PredictInterval returns the conformal prediction interval of x at level 1 - Alpha. The bounds are infinite when
+// there are too few calibration rows for the level, fewer than 1 / Alpha - 1.
+func (c *Conformal) PredictInterval(x []float64) (lower, upper float64) {
+	if c.Alpha <= 0 || c.Alpha >= 1 {
+		panic("Alpha must be in (0, 1)")
+	}
+	n := float64(len(c.Scores))
+	kLower, kUpper := int(math.Floor(c.Alpha*(n+1))), int(math.Ceil((1-c.Alpha)*(n+1)))
+
+	if c.Method == Split {
+		m := c.models[0]
+		width := metrics.OrderStatistic(slices.Clone(c.Scores), kUpper) * m.scale(x)
+		pred := m.estimator.Predict(x)
+		return pred - width, pred + width
+	}
+
+	preds := make([]float64, len(c.models))
+	scales := make([]float64, len(c.models))
+	for k, m := range c.models {
+		preds[k] = m.estimator.Predict(x)
+		scales[k] = m.scale(x)
+	}
+	lows := make([]float64, len(c.Scores))
+	highs := make([]float64, len(c.Scores))
+	for i, score := range c.Scores {
+		k := c.rowFold[i]
+		lows[i] = preds[k] - score*scales[k]
+		highs[i] = preds[k] + score*scales[k]
+	}
+	return metrics.OrderStatistic(lows, kLower), metrics.OrderStatistic(highs, kUpper)
+}
+
+func (c *Conformal) GetMetrics() metrics.Metrics {
+	return c.Metrics
+}
@@ -0,0 +1,67 @@
+package conformal_test
+
+import (
+	"GoML/Ensemble"
+	"GoML/OLS"
+	"GoML/conformal"
+	"math/rand"
+	"testing"
+)
+
+// heteroscedastic draws y = 2x + noise whose spread grows with x, so that normalized intervals have something to adapt to.
+func heteroscedastic(rng *rand.Rand, nRows int) ([][]float64, []float64) {
+	x := make([][]float64, nRows)
+	y := make([]float64, nRows)
+	for i := range x {
+		x[i] = []float64{10 * rng.Float64()}
+		y[i] = 2*x[i][0] + (1+x[i][0])*rng.NormFloat64()
+	}
+	return x, y
+}
+
+// Averaged over many draws of the data, the intervals contain about 1 - alpha of new targets: split conformal at
+// least 1 - alpha and at most 1 / (nCalibration + 1) more, CV+ close to 1 - alpha in practice.
+func TestCoverage(t *testing.T) {
+	const alpha, nTrials, nTrain, nTest = 0.1, 100, 200, 200
+	newMethods := map[string]func(x [][]float64, y []float64, seed *int64) *conformal.Conformal{
+		"split": func(x [][]float64, y []float64, seed *int64) *conformal.Conformal {
+			return conformal.NewSplitConformal(OLS.NewOLS, x, y, alpha, 0.25, seed).(*conformal.Conformal)
+		},
+		"cv+": func(x [][]float64, y []float64, seed *int64) *conformal.Conformal {
+			return conformal.NewCVPlusConformal(OLS.NewOLS, x, y, alpha, 5, seed).(*conformal.Conformal)
+		},
+	}
+	difficulties := map[string]func(x [][]float64, y []float64) Ensemble.Estimator{
+		"absolute":   nil,
+		"normalized": OLS.NewOLS,
+	}
+
+	for methodName, newMethod := range newMethods {
+		for difficultyName, difficulty := range difficulties {
+			t.Run(methodName+"/"+difficultyName, func(t *testing.T) {
+				rng := rand.New(rand.NewSource(1))
+				covered := 0
+				for trial := 0; trial < nTrials; trial++ {
+					x, y := heteroscedastic(rng, nTrain)
+					seed := int64(trial)
+					model := newMethod(x, y, &seed)
+					model.DifficultyFactory = difficulty
+					model.Fit()
+
+					xTest, yTest := heteroscedastic(rng, nTest)
+					for i, row := range xTest {
+						lower, upper := model.PredictInterval(row)
+						if lower <= yTest[i] && yTest[i] <= upper {
+							covered++
+						}
+					}
+				}
+
+				coverage := float64(covered) / (nTrials * nTest)
+				if coverage < 1-alpha-0.015 || coverage > 1-alpha+0.035 {
+					t.Errorf("coverage %v, want about %v", coverage, 1-alpha)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package crossval
+
+import "math/rand"
+
+type Fold struct {
+	Train []int
+	Test  []int
+}
+
+// KFold partitions the row indices [0, nRows) into k folds of near equal size.
+// Rows are shuffled with rng before partitioning; a nil rng keeps the original row order.
+func KFold(nRows, k int, rng *rand.Rand) []Fold {
+	if k < 2 || k > nRows {
+		panic("k must be in [2, nRows]")
+	}
+
+	order := make([]int, nRows)
+	for i := range order {
+		order[i] = i
+	}
+	if rng != nil {
+		rng.Shuffle(nRows, func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	}
+
+	folds := make([]Fold, k)
+	start := 0
+	for f := 0; f < k; f++ {
+		size := nRows / k
+		if f < nRows%k {
+			size++
+		}
+		test := order[start : start+size]
+
+		train := make([]int, 0, nRows-size)
+		train = append(train, order[:start]...)
+		train = append(train, order[start+size:]...)
+
+		folds[f] = Fold{Train: train, Test: test}
+		start += size
+	}
+	return folds
+}
@@ -0,0 +1,52 @@
+package crossval
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestKFold(t *testing.T) {
+	for _, rng := range []*rand.Rand{nil, rand.New(rand.NewSource(1))} {
+		folds := KFold(11, 3, rng)
+		if len(folds) != 3 {
+			t.Fatalf("%d folds, want 3", len(folds))
+		}
+
+		var tested []int
+		for f, fold := range folds {
+			// 11 rows split as 4, 4 and 3
+			if want := []int{4, 4, 3}[f]; len(fold.Test) != want || len(fold.Train) != 11-want {
+				t.Errorf("fold %d: %d test and %d train rows, want %d and %d", f, len(fold.Test), len(fold.Train), want, 11-want)
+			}
+			for _, row := range fold.Test {
+				if slices.Contains(fold.Train, row) {
+					t.Errorf("fold %d: row %d is both trained and tested on", f, row)
+				}
+			}
+			tested = append(tested, fold.Test...)
+		}
+		slices.Sort(tested)
+		if !slices.Equal(tested, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+			t.Errorf("test rows %v, want every row once", tested)
+		}
+	}
+
+	if folds := KFold(4, 2, nil); !slices.Equal(folds[0].Test, []int{0, 1}) || !slices.Equal(folds[1].Test, []int{2, 3}) {
+		t.Errorf("folds without rng %v, want the rows in order", folds)
+	}
+}
+
+func TestLogSpace(t *testing.T) {
+	got := LogSpace(1e3, 1e-1, 5)
+	for i, want := range []float64{1e3, 1e2, 1e1, 1, 1e-1} {
+		if math.Abs(got[i]-want) > 1e-12*want {
+			t.Errorf("LogSpace(1e3, 1e-1, 5) = %v", got)
+			break
+		}
+	}
+	if got := LogSpace(5, 50, 1); !slices.Equal(got, []float64{5}) {
+		t.Errorf("LogSpace with a single point = %v, want the start", got)
+	}
+}
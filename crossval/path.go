@@ -0,0 +1,28 @@
+package crossval
+
+import "math"
+
+// PathPoint is a single alpha of the regularization path of a cross-validated linear model. Coefs are fitted on the
+// full data while the validation error is averaged across the k folds.
+type PathPoint struct {
+	Alpha         float64   `json:"alpha"`
+	Coefs         []float64 `json:"coefs"`
+	Intercept     float64   `json:"intercept"`
+	NonZero       int       `json:"n_nonzero"`
+	ValidationMSE float64   `json:"validation_mse"`
+	ValidationStd float64   `json:"validation_std"`
+}
+
+// LogSpace returns n points evenly spaced on a log10 scale from start to stop (inclusive), in that order.
+// A single point is start.
+func LogSpace(start, stop float64, n int) []float64 {
+	if n == 1 {
+		return []float64{start}
+	}
+	out := make([]float64, n)
+	logStart, logStop := math.Log10(start), math.Log10(stop)
+	for i := range out {
+		out[i] = math.Pow(10, logStart+(logStop-logStart)*float64(i)/float64(n-1))
+	}
+	return out
+}
@@ -2,9 +2,18 @@ package demo
 
 import (
 	"GoML/DecTree"
+	"GoML/ElasticNet"
 	"GoML/Ensemble"
+	"GoML/Forest"
+	"GoML/GLM"
+	"GoML/HistGB"
 	"GoML/LinReg"
+	"GoML/LogReg"
 	"GoML/OLS"
+	"GoML/QuantReg"
+	"GoML/Ridge"
+	"GoML/Robust"
+	"GoML/conformal"
 
 	"encoding/json"
 	"fmt"
@@ -12,14 +21,44 @@ import (
 )
 
 var Models = map[string]func(x [][]float64, y []float64) Ensemble.Estimator{
-	"linreg":  LinReg.NewLinReg,
-	"ols":     OLS.NewOLS,
-	"dectree": DecTree.NewDefaultDecTree,
+	"linreg":            LinReg.NewLinReg,
+	"ols":               OLS.NewOLS,
+	"dectree":           DecTree.NewDefaultDecTree,
+	"ridge":             Ridge.NewDefaultRidge,
+	"lasso":             ElasticNet.NewDefaultLasso,
+	"elasticnet":        ElasticNet.NewDefaultElasticNet,
+	"ridgecv":           Ridge.NewDefaultRidgeCV,
+	"lassocv":           ElasticNet.NewDefaultLassoCV,
+	"elasticnetcv":      ElasticNet.NewDefaultElasticNetCV,
+	"huber":             Robust.NewDefaultHuber,
+	"theilsen":          Robust.NewDefaultTheilSen,
+	"quantreg":          QuantReg.NewDefaultQuantReg,
+	"poisson":           GLM.NewPoissonGLM,
+	"gamma":             GLM.NewGammaGLM,
+	"histgb":            HistGB.NewDefaultHistGradientBoosting,
+	"randomforest":      Forest.NewDefaultRandomForest,
+	"extratrees":        Forest.NewDefaultExtraTrees,
+	"logistic":          LogReg.NewDefaultLogReg,
+	"dectreeclassifier": DecTree.NewDefaultDecTreeClassifier,
+	"tweedie": func(x [][]float64, y []float64) Ensemble.Estimator {
+		return GLM.NewTweedieGLM(x, y, 1.5)
+	},
+	"ransac": func(x [][]float64, y []float64) Ensemble.Estimator {
+		return Robust.NewDefaultRANSAC(OLS.NewOLS, x, y)
+	},
 }
 
 var EnsembleType = map[string]func(estimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator, nEstimators int, x [][]float64, y []float64) Ensemble.Estimator{
-	"bagged":  Ensemble.NewDefaultBagged,
-	"boosted": Ensemble.NewDefaultBoosted,
+	"bagged":   Ensemble.NewDefaultBagged,
+	"boosted":  Ensemble.NewDefaultBoosted,
+	"adaboost": Ensemble.NewDefaultAdaBoost,
+	// Conformal intervals are calibrated with CV+ over nEstimators folds, or on a held-out split for a single estimator
+	"conformal": func(estimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator, nEstimators int, x [][]float64, y []float64) Ensemble.Estimator {
+		if nEstimators == 1 {
+			return conformal.NewDefaultConformal(estimatorFactory, x, y)
+		}
+		return conformal.NewCVPlusConformal(estimatorFactory, x, y, 0.1, nEstimators, nil)
+	},
 }
 
 func Run(dummyX [][]float64, dummyY []float64, modelName string, isEnsemble bool, ensembleMethod string, nEstimators int) {
@@ -35,6 +74,18 @@ func Run(dummyX [][]float64, dummyY []float64, modelName string, isEnsemble bool
 		model.Fit()
 	}
 
+	if classifier, ok := model.(Ensemble.Classifier); ok {
+		metricsJSON, _ := json.Marshal(classifier.GetClassificationMetrics())
+		// Split on keys only, the confusion matrix holds commas of its own
+		metricsFormatted := strings.Replace(strings.Join(strings.Split(string(metricsJSON), ",\""), "\n"), "\"", "", -1)
+		fmt.Println("Classification Metrics: ", metricsFormatted)
+
+		testData := dummyX[len(dummyX)-1]
+		fmt.Printf("Predicted class for input %v: %v\n", testData, classifier.PredictClass(testData))
+		fmt.Printf("Class probabilities %v: %v\n", classifier.GetClasses(), classifier.PredictProba(testData))
+		return
+	}
+
 	//fmt.Println("Coefficients: ", model.Coef)
 	metricsJSON, _ := json.Marshal(model.GetMetrics())
 	metricsFormatted := strings.Join(strings.Split(strings.Replace(string(metricsJSON), "\"", "", -1), ","), "\n")
@@ -44,5 +95,9 @@ func Run(dummyX [][]float64, dummyY []float64, modelName string, isEnsemble bool
 	testData := dummyX[len(dummyX)-1]
 	prediction := model.Predict(testData)
 	fmt.Printf("Prediction for input %v: %v\n", testData, prediction)
+	if intervalModel, ok := model.(Ensemble.IntervalEstimator); ok {
+		lower, upper := intervalModel.PredictInterval(testData)
+		fmt.Printf("Prediction interval: [%v, %v]\n", lower, upper)
+	}
 	return
 }
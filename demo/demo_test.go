@@ -1,12 +1,14 @@
 package demo
 
 import (
+	"GoML/parser"
 	"fmt"
 	"testing"
 )
 
 func TestRun(t *testing.T) {
 	fmt.Println("Starting demo test...")
-	Run()
+	data := parser.LoadData("../test_data.csv", ",", true, 13)
+	Run(data.X, data.Y, "ols", false, "", 0)
 	fmt.Println("Demo test completed.")
 }
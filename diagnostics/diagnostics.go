@@ -0,0 +1,319 @@
+package diagnostics
+
+import (
+	"GoML/LinReg"
+	"GoML/OLS"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Float marshals non-finite values (e.g. the VIF of a perfectly collinear feature) as JSON null.
+type Float float64
+
+func (f Float) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatFloat(float64(f), 'g', -1, 64)), nil
+}
+
+func toFloats(values []float64) []Float {
+	out := make([]Float, len(values))
+	for i, val := range values {
+		out[i] = Float(val)
+	}
+	return out
+}
+
+type TestResult struct {
+	Statistic Float `json:"statistic"`
+	PValue    Float `json:"p_value"`
+	DF        int   `json:"df"`
+}
+
+type NormalityResult struct {
+	TestResult
+	Skew     Float `json:"skew"`
+	Kurtosis Float `json:"kurtosis"`
+}
+
+type Report struct {
+	VIF               []Float         `json:"vif"`
+	DurbinWatson      Float           `json:"durbin_watson"`
+	BreuschPagan      TestResult      `json:"breusch_pagan"`
+	JarqueBera        NormalityResult `json:"jarque_bera"`
+	Leverage          []Float         `json:"leverage"`
+	CooksDistance     []Float         `json:"cooks_distance"`
+	InfluentialPoints []int           `json:"influential_points"` // Rows with Cook's distance above 4/n
+}
+
+// FromOLS diagnoses an unweighted OLS fit. Weighted (WLS) fits are rejected, as the diagnostics assume every row
+// has the same residual variance.
+func FromOLS(ols *OLS.OLS) Report {
+	if len(ols.Coefs) == 0 {
+		panic("OLS model is not fitted yet")
+	}
+	if ols.Weights != nil {
+		panic("Diagnostics do not support weighted fits")
+	}
+	// OLS stores its design matrix with the intercept column prepended
+	x := make([][]float64, len(ols.X))
+	resid := make([]float64, len(ols.X))
+	for i, row := range ols.X {
+		x[i] = row[1:]
+		resid[i] = ols.Y[i] - ols.Predict(x[i])
+	}
+	return FromResiduals(x, resid, true)
+}
+
+// FromLinReg diagnoses an unweighted LinReg fit, rejecting weighted fits like FromOLS.
+func FromLinReg(lr *LinReg.LinReg) Report {
+	if len(lr.Coefs) == 0 {
+		panic("LinReg model is not fitted yet")
+	}
+	if lr.Weights != nil {
+		panic("Diagnostics do not support weighted fits")
+	}
+	resid := make([]float64, len(lr.X))
+	for i, row := range lr.X {
+		resid[i] = lr.Y[i] - lr.Predict(row)
+	}
+	return FromResiduals(lr.X, resid, false)
+}
+
+// FromResiduals runs every diagnostic on the features x (without an intercept column) and the residuals of a
+// linear fit. hasIntercept states whether the fit included an intercept, which changes the hat matrix.
+func FromResiduals(x [][]float64, resid []float64, hasIntercept bool) Report {
+	if len(x) == 0 || len(x) != len(resid) {
+		panic("X and residuals must be non-empty and have the same number of rows")
+	}
+
+	design := x
+	if hasIntercept {
+		design = withIntercept(x)
+	}
+	leverage, rank := Leverage(design)
+	cooks := CooksDistance(resid, leverage, rank)
+
+	threshold := 4 / float64(len(resid))
+	influential := make([]int, 0)
+	for i, d := range cooks {
+		if d > threshold {
+			influential = append(influential, i)
+		}
+	}
+
+	bpStat, bpP, bpDF := BreuschPagan(x, resid)
+	jbStat, jbP, skew, kurt := JarqueBera(resid)
+
+	return Report{
+		VIF:               toFloats(VIF(x)),
+		DurbinWatson:      Float(DurbinWatson(resid)),
+		BreuschPagan:      TestResult{Statistic: Float(bpStat), PValue: Float(bpP), DF: bpDF},
+		JarqueBera:        NormalityResult{TestResult: TestResult{Statistic: Float(jbStat), PValue: Float(jbP), DF: 2}, Skew: Float(skew), Kurtosis: Float(kurt)},
+		Leverage:          toFloats(leverage),
+		CooksDistance:     toFloats(cooks),
+		InfluentialPoints: influential,
+	}
+}
+
+func withIntercept(x [][]float64) [][]float64 {
+	out := make([][]float64, len(x))
+	for i, row := range x {
+		out[i] = make([]float64, len(row)+1)
+		out[i][0] = 1.0
+		copy(out[i][1:], row)
+	}
+	return out
+}
+
+func toDense(x [][]float64) *mat.Dense {
+	nRows, nCols := len(x), len(x[0])
+	flattened := make([]float64, 0, nRows*nCols)
+	for _, row := range x {
+		flattened = append(flattened, row...)
+	}
+	return mat.NewDense(nRows, nCols, flattened)
+}
+
+// auxiliaryR2 regresses target on x (plus an intercept) and returns the centered R2 of the fit.
+func auxiliaryR2(x [][]float64, target []float64) float64 {
+	design := toDense(withIntercept(x))
+
+	var svd mat.SVD
+	ok := svd.Factorize(design, mat.SVDThin)
+	if !ok {
+		panic("SVD Factorization Failed")
+	}
+	rank := 0
+	for _, s := range svd.Values(nil) {
+		if s > 1e-8 {
+			rank++
+		}
+	}
+
+	var beta mat.Dense
+	svd.SolveTo(&beta, mat.NewVecDense(len(target), target), rank)
+	var fitted mat.Dense
+	fitted.Mul(design, &beta)
+
+	mean := stat.Mean(target, nil)
+	SSR, SST := 0.0, 0.0
+	for i, val := range target {
+		SSR += (val - fitted.At(i, 0)) * (val - fitted.At(i, 0))
+		SST += (val - mean) * (val - mean)
+	}
+	return 1 - SSR/SST
+}
+
+// VIF returns the variance inflation factor 1 / (1 - R2_j) of every feature, where R2_j is the R2 of regressing
+// feature j on all the others. Perfectly collinear features get +Inf and constant features NaN.
+func VIF(x [][]float64) []float64 {
+	nRows, nCols := len(x), len(x[0])
+	vif := make([]float64, nCols)
+	for j := 0; j < nCols; j++ {
+		others := make([][]float64, nRows)
+		target := make([]float64, nRows)
+		for i, row := range x {
+			others[i] = make([]float64, 0, nCols-1)
+			others[i] = append(others[i], row[:j]...)
+			others[i] = append(others[i], row[j+1:]...)
+			target[i] = row[j]
+		}
+
+		var r2 float64
+		if nCols == 1 {
+			r2 = 0
+			if stat.Variance(target, nil) == 0 {
+				r2 = math.NaN()
+			}
+		} else {
+			r2 = auxiliaryR2(others, target)
+		}
+		if r2 > 1-1e-12 {
+			vif[j] = math.Inf(1)
+		} else {
+			vif[j] = 1 / (1 - r2)
+		}
+	}
+	return vif
+}
+
+// DurbinWatson tests first order autocorrelation of residuals. Values near 2 indicate no autocorrelation,
+// values towards 0 positive and towards 4 negative autocorrelation.
+func DurbinWatson(resid []float64) float64 {
+	num, den := 0.0, 0.0
+	for i, e := range resid {
+		den += e * e
+		if i > 0 {
+			num += (e - resid[i-1]) * (e - resid[i-1])
+		}
+	}
+	return num / den
+}
+
+// BreuschPagan runs the studentized (Koenker) Breusch-Pagan test for heteroscedasticity by regressing the squared
+// residuals on the features. The LM statistic n*R2 is chi-squared with one degree of freedom per feature under
+// homoscedasticity.
+func BreuschPagan(x [][]float64, resid []float64) (statistic, pValue float64, df int) {
+	sq := make([]float64, len(resid))
+	for i, e := range resid {
+		sq[i] = e * e
+	}
+	df = len(x[0])
+	statistic = float64(len(resid)) * auxiliaryR2(x, sq)
+	pValue = 1 - distuv.ChiSquared{K: float64(df)}.CDF(statistic)
+	return
+}
+
+// JarqueBera tests residual normality from the sample skewness and kurtosis. The statistic is chi-squared with
+// two degrees of freedom under normality.
+func JarqueBera(resid []float64) (statistic, pValue, skew, kurtosis float64) {
+	n := float64(len(resid))
+	mean := stat.Mean(resid, nil)
+
+	m2, m3, m4 := 0.0, 0.0, 0.0
+	for _, e := range resid {
+		d := e - mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	m2, m3, m4 = m2/n, m3/n, m4/n
+
+	skew = m3 / math.Pow(m2, 1.5)
+	kurtosis = m4 / (m2 * m2)
+	statistic = n / 6 * (skew*skew + (kurtosis-3)*(kurtosis-3)/4)
+	pValue = 1 - distuv.ChiSquared{K: 2}.CDF(statistic)
+	return
+}
+
+// Leverage returns the diagonal of the hat matrix X * pinv(X^T X) * X^T of the design matrix
+// (including the intercept column if the model has one), along with the numerical rank of the design.
+func Leverage(design [][]float64) (leverage []float64, rank int) {
+	var svd mat.SVD
+	ok := svd.Factorize(toDense(design), mat.SVDThin)
+	if !ok {
+		panic("SVD Factorization Failed")
+	}
+	for _, s := range svd.Values(nil) {
+		if s > 1e-8 {
+			rank++
+		}
+	}
+
+	var u mat.Dense
+	svd.UTo(&u)
+	leverage = make([]float64, len(design))
+	for i := range design {
+		for k := 0; k < rank; k++ {
+			leverage[i] += u.At(i, k) * u.At(i, k)
+		}
+	}
+	return
+}
+
+// CooksDistance measures the influence of each row on the fit: e_i^2 / (p * s^2) * h_i / (1 - h_i)^2
+// where p is the number of estimated parameters (the rank of the design) and s^2 the residual variance.
+func CooksDistance(resid, leverage []float64, p int) []float64 {
+	SSR := 0.0
+	for _, e := range resid {
+		SSR += e * e
+	}
+	s2 := SSR / float64(len(resid)-p)
+
+	cooks := make([]float64, len(resid))
+	for i, e := range resid {
+		h := leverage[i]
+		cooks[i] = e * e / (float64(p) * s2) * h / ((1 - h) * (1 - h))
+	}
+	return cooks
+}
+
+// String renders the report as text, listing per-feature VIFs and the influential rows.
+func (r Report) String() string {
+	width := 78
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%*s\n", (width+len("Regression Diagnostics"))/2, "Regression Diagnostics"))
+	sb.WriteString(strings.Repeat("=", width) + "\n")
+	sb.WriteString(fmt.Sprintf("%-30s%14.4f\n", "Durbin-Watson:", float64(r.DurbinWatson)))
+	sb.WriteString(fmt.Sprintf("%-30s%14.4f    p-value: %.4g (df=%d)\n", "Breusch-Pagan LM:", float64(r.BreuschPagan.Statistic), float64(r.BreuschPagan.PValue), r.BreuschPagan.DF))
+	sb.WriteString(fmt.Sprintf("%-30s%14.4f    p-value: %.4g (df=%d)\n", "Jarque-Bera:", float64(r.JarqueBera.Statistic), float64(r.JarqueBera.PValue), r.JarqueBera.DF))
+	sb.WriteString(fmt.Sprintf("%-30s%14.4f\n", "Skew:", float64(r.JarqueBera.Skew)))
+	sb.WriteString(fmt.Sprintf("%-30s%14.4f\n", "Kurtosis:", float64(r.JarqueBera.Kurtosis)))
+	sb.WriteString(strings.Repeat("-", width) + "\n")
+	sb.WriteString(fmt.Sprintf("%-12s%14s\n", "feature", "VIF"))
+	for j, vif := range r.VIF {
+		sb.WriteString(fmt.Sprintf("%-12s%14.4f\n", fmt.Sprintf("x%d", j), float64(vif)))
+	}
+	sb.WriteString(strings.Repeat("-", width) + "\n")
+	sb.WriteString(fmt.Sprintf("Influential points (Cook's distance > 4/n): %v\n", r.InfluentialPoints))
+	sb.WriteString(strings.Repeat("=", width) + "\n")
+	return sb.String()
+}
@@ -0,0 +1,90 @@
+package diagnostics
+
+import (
+	"GoML/OLS"
+	"GoML/parser"
+	"math"
+	"slices"
+	"testing"
+)
+
+// Reference values for the OLS fit MEDV ~ RM + PTRATIO + LSTAT on test_data.csv, computed exactly from the normal
+// equations (they agree with statsmodels' durbin_watson, het_breuschpagan, jarque_bera, variance_inflation_factor
+// and OLSInfluence).
+func fitHousing(t *testing.T, weights []float64) *OLS.OLS {
+	t.Helper()
+	data := parser.LoadData("../test_data.csv", ",", true, 13)
+	x := make([][]float64, len(data.X))
+	for i, row := range data.X {
+		x[i] = []float64{row[5], row[10], row[12]}
+	}
+	var ols *OLS.OLS
+	if weights == nil {
+		ols = OLS.NewOLS(x, data.Y).(*OLS.OLS)
+	} else {
+		ols = OLS.NewWLS(x, data.Y, weights).(*OLS.OLS)
+	}
+	ols.Fit()
+	return ols
+}
+
+func TestFromOLS(t *testing.T) {
+	report := FromOLS(fitHousing(t, nil))
+
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"DurbinWatson", float64(report.DurbinWatson), 0.9477195336326598},
+		{"BreuschPagan", float64(report.BreuschPagan.Statistic), 9.186874864292468},
+		{"BreuschPagan p-value", float64(report.BreuschPagan.PValue), 0.02690674811307793},
+		{"JarqueBera", float64(report.JarqueBera.Statistic), 0.8057980329548677},
+		{"JarqueBera p-value", float64(report.JarqueBera.PValue), 0.668379591241252},
+		{"Skew", float64(report.JarqueBera.Skew), 0.3223767706124512},
+		{"Kurtosis", float64(report.JarqueBera.Kurtosis), 2.521532344414126},
+		{"VIF RM", float64(report.VIF[0]), 1.9503315388376459},
+		{"VIF PTRATIO", float64(report.VIF[1]), 1.078715496330067},
+		{"VIF LSTAT", float64(report.VIF[2]), 1.8584394237109632},
+		{"Leverage 0", float64(report.Leverage[0]), 0.19157471278863514},
+		{"Leverage 1", float64(report.Leverage[1]), 0.06469201135330765},
+		{"Leverage 2", float64(report.Leverage[2]), 0.22683886587932559},
+		{"CooksDistance 0", float64(report.CooksDistance[0]), 0.15186641417103644},
+		{"CooksDistance 1", float64(report.CooksDistance[1]), 0.019486684335467606},
+		{"CooksDistance 2", float64(report.CooksDistance[2]), 0.061914653549339366},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-8*math.Max(1, math.Abs(tt.want)) {
+			t.Errorf("%s = %.10g, want %.10g", tt.name, tt.got, tt.want)
+		}
+	}
+	if report.BreuschPagan.DF != 3 {
+		t.Errorf("BreuschPagan.DF = %d, want 3", report.BreuschPagan.DF)
+	}
+	if want := []int{0, 4, 7, 8, 10}; !slices.Equal(report.InfluentialPoints, want) {
+		t.Errorf("InfluentialPoints = %v, want %v", report.InfluentialPoints, want)
+	}
+}
+
+func TestFromOLSRejectsWeightedFits(t *testing.T) {
+	weights := make([]float64, 30)
+	for i := range weights {
+		weights[i] = float64(i + 1)
+	}
+	ols := fitHousing(t, weights)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("FromOLS accepted a weighted fit")
+		}
+	}()
+	FromOLS(ols)
+}
+
+func TestVIFCollinear(t *testing.T) {
+	x := [][]float64{{1, 2}, {2, 4}, {3, 6}, {4, 8}}
+	for j, vif := range VIF(x) {
+		if !math.IsInf(vif, 1) {
+			t.Errorf("VIF[%d] = %v, want +Inf for collinear features", j, vif)
+		}
+	}
+}
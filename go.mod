@@ -2,4 +2,4 @@ module GoML
 
 go 1.25.0
 
-require gonum.org/v1/gonum v0.16.0 // indirect
+require gonum.org/v1/gonum v0.16.0
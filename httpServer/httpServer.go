@@ -29,23 +29,71 @@ func AbstractHandler(getHandler, postHandler func(w http.ResponseWriter, r *http
 var LinRegHandler = AbstractHandler(LinRegGetHandler, LinRegPostHandler)
 var OLSHandler = AbstractHandler(OLSGetHandler, OLSPostHandler)
 var DecTreeHandler = AbstractHandler(DecTreeGetHandler, DecTreePostHandler)
+var GLSHandler = AbstractHandler(GLSGetHandler, GLSPostHandler)
+var RidgeHandler = AbstractHandler(RidgeGetHandler, RidgePostHandler)
+var LassoHandler = AbstractHandler(LassoGetHandler, LassoPostHandler)
+var ElasticNetHandler = AbstractHandler(ElasticNetGetHandler, ElasticNetPostHandler)
+var HuberHandler = AbstractHandler(HuberGetHandler, HuberPostHandler)
+var QuantRegHandler = AbstractHandler(QuantRegGetHandler, QuantRegPostHandler)
+var GLMHandler = AbstractHandler(GLMGetHandler, GLMPostHandler)
+var HistGBHandler = AbstractHandler(HistGBGetHandler, HistGBPostHandler)
+var LogRegHandler = AbstractHandler(LogRegGetHandler, LogRegPostHandler)
+var DecTreeClassifierHandler = AbstractHandler(DecTreeClassifierGetHandler, DecTreeClassifierPostHandler)
+var RANSACHandler = AbstractHandler(RANSACGetHandler, RANSACPostHandler)
+var TheilSenHandler = AbstractHandler(TheilSenGetHandler, TheilSenPostHandler)
+var ConformalHandler = AbstractHandler(ConformalGetHandler, ConformalPostHandler)
+var RidgeCVHandler = AbstractHandler(RidgeCVGetHandler, RidgeCVPostHandler)
+var LassoCVHandler = AbstractHandler(LassoCVGetHandler, LassoCVPostHandler)
+var ElasticNetCVHandler = AbstractHandler(ElasticNetCVGetHandler, ElasticNetCVPostHandler)
+var RandomForestHandler = AbstractHandler(RandomForestGetHandler, RandomForestPostHandler)
+var ExtraTreesHandler = AbstractHandler(ExtraTreesGetHandler, ExtraTreesPostHandler)
 var BaggedHandler = AbstractHandler(BaggedGetHandler, BaggedPostHandler)
 var BoostedHandler = AbstractHandler(BoostedGetHandler, BoostedPostHandler)
+var AdaBoostHandler = AbstractHandler(AdaBoostGetHandler, AdaBoostPostHandler)
+var StackedHandler = AbstractHandler(StackedGetHandler, StackedPostHandler)
+var VotingHandler = AbstractHandler(VotingGetHandler, VotingPostHandler)
+var DiagnosticsHandler = AbstractHandler(DiagnosticsGetHandler, DiagnosticsPostHandler)
 
 func StartServer(port string) error {
 	// Top level routes
 	http.HandleFunc("/models", ModelsHandler)
 	http.HandleFunc("/estimators", EstimatorsHandler)
+	http.HandleFunc("/classifiers", ClassifiersHandler)
 	http.HandleFunc("/ensembles", EnsemblesHandler)
 
 	// Model specific routes
 	http.HandleFunc("/models/linreg", LinRegHandler)
 	http.HandleFunc("/models/ols", OLSHandler)
 	http.HandleFunc("/models/dectree", DecTreeHandler)
+	http.HandleFunc("/models/gls", GLSHandler)
+	http.HandleFunc("/models/ridge", RidgeHandler)
+	http.HandleFunc("/models/lasso", LassoHandler)
+	http.HandleFunc("/models/elasticnet", ElasticNetHandler)
+	http.HandleFunc("/models/huber", HuberHandler)
+	http.HandleFunc("/models/quantreg", QuantRegHandler)
+	http.HandleFunc("/models/glm", GLMHandler)
+	http.HandleFunc("/models/histgb", HistGBHandler)
+	http.HandleFunc("/models/randomforest", RandomForestHandler)
+	http.HandleFunc("/models/extratrees", ExtraTreesHandler)
+	http.HandleFunc("/models/ransac", RANSACHandler)
+	http.HandleFunc("/models/theilsen", TheilSenHandler)
+	http.HandleFunc("/models/conformal", ConformalHandler)
+	http.HandleFunc("/models/ridgecv", RidgeCVHandler)
+	http.HandleFunc("/models/lassocv", LassoCVHandler)
+	http.HandleFunc("/models/elasticnetcv", ElasticNetCVHandler)
 
+	// Classifier specific routes
+	http.HandleFunc("/models/logistic", LogRegHandler)
+	http.HandleFunc("/models/dectreeclassifier", DecTreeClassifierHandler)
 	// Ensemble specific routes
 	http.HandleFunc("/ensembles/bagged", BaggedHandler)
 	http.HandleFunc("/ensembles/boosted", BoostedHandler)
+	http.HandleFunc("/ensembles/adaboost", AdaBoostHandler)
+	http.HandleFunc("/ensembles/stacked", StackedHandler)
+	http.HandleFunc("/ensembles/voting", VotingHandler)
+
+	// Diagnostics
+	http.HandleFunc("/diagnostics", DiagnosticsHandler)
 
 	//Status
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -2,15 +2,94 @@ package httpServer
 
 import (
 	"GoML/DecTree"
+	"GoML/ElasticNet"
 	"GoML/Ensemble"
+	"GoML/Forest"
+	"GoML/GLM"
+	"GoML/HistGB"
 	"GoML/LinReg"
+	"GoML/LogReg"
 	"GoML/OLS"
+	"GoML/QuantReg"
+	"GoML/Ridge"
+	"GoML/Robust"
+	"GoML/conformal"
+	"GoML/diagnostics"
 	"GoML/metrics"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"slices"
 )
 
+// floatParam, intParam and boolParam read optional base estimator params decoded from JSON,
+// falling back to the given default when the key is missing or has the wrong type.
+func floatParam(params map[string]interface{}, key string, def float64) float64 {
+	if val, ok := params[key].(float64); ok {
+		return val
+	}
+	return def
+}
+
+func intParam(params map[string]interface{}, key string, def int) int {
+	if val, ok := params[key].(float64); ok {
+		return int(val)
+	}
+	return def
+}
+
+func boolParam(params map[string]interface{}, key string, def bool) bool {
+	if val, ok := params[key].(bool); ok {
+		return val
+	}
+	return def
+}
+
+// glmFamily resolves the family and link names of a GLM request. An empty link name uses the family's default link.
+func glmFamily(familyName, linkName string, power float64) (GLM.Family, GLM.Link, error) {
+	var family GLM.Family
+	switch familyName {
+	case "", "gaussian":
+		family = GLM.Gaussian{}
+	case "poisson":
+		family = GLM.Poisson{}
+	case "gamma":
+		family = GLM.Gamma{}
+	case "tweedie":
+		family = GLM.Tweedie{Power: power}
+	default:
+		return nil, nil, errors.New("unsupported family")
+	}
+
+	switch linkName {
+	case "":
+		return family, family.DefaultLink(), nil
+	case "identity":
+		return family, GLM.IdentityLink{}, nil
+	case "log":
+		return family, GLM.LogLink{}, nil
+	default:
+		return nil, nil, errors.New("unsupported link")
+	}
+}
+
+// treeCriterion resolves the split criterion of a regression tree, an empty name being the default MSE.
+func treeCriterion(name string) (DecTree.Criterion, error) {
+	if name == "" {
+		return DecTree.MSE{}, nil
+	}
+	criterion, err := DecTree.CriterionByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := criterion.(DecTree.ClassCriterion); ok {
+		return nil, errors.New("classification criterion on a regression tree")
+	}
+	return criterion, nil
+}
+
 func ensembleFactoryConstructor(baseModel string, baseEstimatorParams map[string]interface{}) (func(x [][]float64, y []float64) Ensemble.Estimator, error) {
 	var baseEstimatorFactory func(x [][]float64, y []float64) Ensemble.Estimator
 	switch baseModel {
@@ -19,27 +98,349 @@ func ensembleFactoryConstructor(baseModel string, baseEstimatorParams map[string
 	case "ols":
 		baseEstimatorFactory = OLS.NewOLS
 	case "dectree":
+		criterionName, _ := baseEstimatorParams["criterion"].(string)
+		criterion, err := treeCriterion(criterionName)
+		if err != nil {
+			return nil, err
+		}
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			var randSeed *int64
+			if seed, ok := baseEstimatorParams["random_seed"].(float64); ok {
+				s := int64(seed)
+				randSeed = &s
+			}
+			maxFeatures := intParam(baseEstimatorParams, "max_features", 0)
+			var tree *DecTree.DecTree
+			if quantile, ok := baseEstimatorParams["quantile"].(float64); ok {
+				tree = DecTree.NewQuantileDecTree(x, y,
+					intParam(baseEstimatorParams, "max_depth", 10),
+					intParam(baseEstimatorParams, "min_samples_split", 2),
+					intParam(baseEstimatorParams, "min_samples_leaf", 1),
+					quantile,
+					randSeed,
+					&maxFeatures).(*DecTree.DecTree)
+			} else {
+				tree = DecTree.NewDecTree(x, y,
+					intParam(baseEstimatorParams, "max_depth", 10),
+					intParam(baseEstimatorParams, "min_samples_split", 2),
+					intParam(baseEstimatorParams, "min_samples_leaf", 1),
+					randSeed,
+					&maxFeatures).(*DecTree.DecTree)
+			}
+			tree.Criterion = criterion
+			return tree
+		}
+	case "randomforest", "extratrees":
+		criterionName, _ := baseEstimatorParams["criterion"].(string)
+		criterion, err := treeCriterion(criterionName)
+		if err != nil {
+			return nil, err
+		}
+		newForest := Forest.NewRandomForest
+		if baseModel == "extratrees" {
+			newForest = Forest.NewExtraTrees
+		}
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			var randSeed *int64
+			if seed, ok := baseEstimatorParams["random_seed"].(float64); ok {
+				s := int64(seed)
+				randSeed = &s
+			}
+			forest := newForest(x, y,
+				intParam(baseEstimatorParams, "n_estimators", 100),
+				intParam(baseEstimatorParams, "max_depth", 10),
+				intParam(baseEstimatorParams, "min_samples_split", 2),
+				intParam(baseEstimatorParams, "min_samples_leaf", 1),
+				intParam(baseEstimatorParams, "max_features", 0),
+				randSeed)
+			forestOf(forest).Criterion = criterion
+			return forest
+		}
+	case "ridge":
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			return Ridge.NewRidge(x, y,
+				floatParam(baseEstimatorParams, "alpha", 1.0),
+				boolParam(baseEstimatorParams, "fit_intercept", true))
+		}
+	case "lasso":
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			return ElasticNet.NewLasso(x, y,
+				floatParam(baseEstimatorParams, "alpha", 1.0),
+				intParam(baseEstimatorParams, "max_iter", 1000),
+				floatParam(baseEstimatorParams, "tol", 1e-4),
+				boolParam(baseEstimatorParams, "fit_intercept", true))
+		}
+	case "elasticnet":
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			return ElasticNet.NewElasticNet(x, y,
+				floatParam(baseEstimatorParams, "alpha", 1.0),
+				floatParam(baseEstimatorParams, "l1_ratio", 0.5),
+				intParam(baseEstimatorParams, "max_iter", 1000),
+				floatParam(baseEstimatorParams, "tol", 1e-4),
+				boolParam(baseEstimatorParams, "fit_intercept", true))
+		}
+	case "huber":
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			return Robust.NewHuber(x, y,
+				floatParam(baseEstimatorParams, "epsilon", 1.35),
+				intParam(baseEstimatorParams, "max_iter", 100),
+				floatParam(baseEstimatorParams, "tol", 1e-5))
+		}
+	case "quantreg":
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			return QuantReg.NewQuantReg(x, y,
+				floatParam(baseEstimatorParams, "quantile", 0.5),
+				intParam(baseEstimatorParams, "max_iter", 500),
+				floatParam(baseEstimatorParams, "tol", 1e-6))
+		}
+	case "glm":
+		familyName, _ := baseEstimatorParams["family"].(string)
+		linkName, _ := baseEstimatorParams["link"].(string)
+		family, link, err := glmFamily(familyName, linkName, floatParam(baseEstimatorParams, "power", 1.5))
+		if err != nil {
+			return nil, err
+		}
 		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
-			randSeed := int64(baseEstimatorParams["random_seed"].(float64))
-			maxFeatures := int(baseEstimatorParams["max_features"].(float64))
-			return DecTree.NewDecTree(x, y,
-				int(baseEstimatorParams["max_depth"].(float64)),
-				int(baseEstimatorParams["min_samples_split"].(float64)),
-				int(baseEstimatorParams["min_samples_leaf"].(float64)),
-				&randSeed,
+			return GLM.NewGLM(x, y, family, link,
+				intParam(baseEstimatorParams, "max_iter", 100),
+				floatParam(baseEstimatorParams, "tol", 1e-8))
+		}
+	case "histgb":
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			var randSeed *int64
+			if seed, ok := baseEstimatorParams["random_seed"].(float64); ok {
+				s := int64(seed)
+				randSeed = &s
+			}
+			return HistGB.NewHistGradientBoosting(x, y,
+				floatParam(baseEstimatorParams, "learning_rate", 0.1),
+				intParam(baseEstimatorParams, "max_iter", 100),
+				intParam(baseEstimatorParams, "max_leaf_nodes", 31),
+				intParam(baseEstimatorParams, "min_samples_leaf", 20),
+				floatParam(baseEstimatorParams, "l2_regularization", 0),
+				floatParam(baseEstimatorParams, "validation_fraction", 0.1),
+				intParam(baseEstimatorParams, "n_iter_no_change", 10),
+				floatParam(baseEstimatorParams, "tol", 1e-7),
+				randSeed)
+		}
+	case "logistic":
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			return LogReg.NewLogReg(x, y,
+				floatParam(baseEstimatorParams, "alpha", 1.0),
+				boolParam(baseEstimatorParams, "fit_intercept", true),
+				intParam(baseEstimatorParams, "max_iter", 500),
+				floatParam(baseEstimatorParams, "tol", 1e-6))
+		}
+	case "dectreeclassifier":
+		criterion, ok := baseEstimatorParams["criterion"].(string)
+		if !ok {
+			criterion = "gini"
+		}
+		baseEstimatorFactory = func(x [][]float64, y []float64) Ensemble.Estimator {
+			var randSeed *int64
+			if seed, ok := baseEstimatorParams["random_seed"].(float64); ok {
+				s := int64(seed)
+				randSeed = &s
+			}
+			maxFeatures := intParam(baseEstimatorParams, "max_features", 0)
+			return DecTree.NewDecTreeClassifier(x, y,
+				intParam(baseEstimatorParams, "max_depth", 10),
+				intParam(baseEstimatorParams, "min_samples_split", 2),
+				intParam(baseEstimatorParams, "min_samples_leaf", 1),
+				criterion,
+				randSeed,
 				&maxFeatures)
 		}
 	default:
-		return nil, errors.New("unsupported base estimator")
+		return nil, fmt.Errorf("unsupported base estimator %q", baseModel)
 	}
 	return baseEstimatorFactory, nil
 }
 
+// forestOf returns the RandomForest of a RandomForest or ExtraTrees estimator.
+func forestOf(model Ensemble.Estimator) *Forest.RandomForest {
+	if extraTrees, ok := model.(*Forest.ExtraTrees); ok {
+		return extraTrees.RandomForest
+	}
+	return model.(*Forest.RandomForest)
+}
+
+// nullableFloats encodes NaN and infinite values, which JSON cannot represent, as null.
+func nullableFloats(values []float64) []*float64 {
+	nullable := make([]*float64, len(values))
+	for i := range values {
+		if !math.IsNaN(values[i]) && !math.IsInf(values[i], 0) {
+			nullable[i] = &values[i]
+		}
+	}
+	return nullable
+}
+
+// nullableMetrics reports non-finite metrics as null, such as the MAPE of targets with exact zeros.
+func nullableMetrics(m metrics.Metrics) map[string]*float64 {
+	values := nullableFloats([]float64{m.R2, m.MSE, m.RMSE, m.MAE, m.MAPE})
+	return map[string]*float64{"r2": values[0], "mse": values[1], "rmse": values[2], "mae": values[3], "mape": values[4]}
+}
+
+func nullableMetricsList(ms []metrics.Metrics) []map[string]*float64 {
+	out := make([]map[string]*float64, len(ms))
+	for i, m := range ms {
+		out[i] = nullableMetrics(m)
+	}
+	return out
+}
+
+// validate checks the rows to predict against the number of features and returns the interval alpha.
+func (body PredictionPostBody) validate(nFeatures int) (float64, error) {
+	alpha := 0.1
+	if body.IntervalAlpha != nil {
+		alpha = *body.IntervalAlpha
+	}
+	if alpha <= 0 || alpha >= 1 {
+		return 0, errors.New("interval_alpha must be in (0, 1)")
+	}
+	for _, row := range body.XPredict {
+		if len(row) != nFeatures {
+			return 0, errors.New("X_predict rows must have as many features as X")
+		}
+	}
+	return alpha, nil
+}
+
+// uncertaintyPredictions predicts every row of X with the spread of the estimators and the jackknife+ interval.
+func uncertaintyPredictions(bagged *Ensemble.Bagged, X [][]float64, alpha float64) map[string][]*float64 {
+	columns := map[string][]float64{}
+	for _, key := range []string{"mean", "std", "lower", "upper", "jackknife_lower", "jackknife_upper"} {
+		columns[key] = make([]float64, len(X))
+	}
+	for i, row := range X {
+		uncertainty := bagged.PredictWithUncertainty(row, alpha)
+		columns["mean"][i] = uncertainty.Mean
+		columns["std"][i] = uncertainty.Std
+		columns["lower"][i] = uncertainty.Lower
+		columns["upper"][i] = uncertainty.Upper
+		columns["jackknife_lower"][i], columns["jackknife_upper"][i] = bagged.JackknifePlusInterval(row, alpha)
+	}
+
+	predictions := make(map[string][]*float64, len(columns))
+	for key, column := range columns {
+		predictions[key] = nullableFloats(column)
+	}
+	return predictions
+}
+
+var predictionsDescription = map[string]string{
+	"mean":            "[pred1, pred2, ...] // mean prediction of the estimators for each X_predict row",
+	"std":             "[std1, std2, ...] // standard deviation of the estimator predictions",
+	"lower":           "[float, ...] // interval_alpha / 2 percentile of the estimator predictions",
+	"upper":           "[float, ...] // 1 - interval_alpha / 2 percentile of the estimator predictions",
+	"jackknife_lower": "[float, ...] // lower bound of the jackknife+-after-bootstrap prediction interval, null when unbounded",
+	"jackknife_upper": "[float, ...] // upper bound of the jackknife+-after-bootstrap prediction interval, null when unbounded",
+}
+
+// bagFeatures returns the feature subset of every bag, nil entries holding every feature.
+func bagFeatures(bags []Ensemble.Sample) [][]int {
+	features := make([][]int, len(bags))
+	for i, bag := range bags {
+		features[i] = bag.Features
+	}
+	return features
+}
+
+// weightedFactoryConstructor returns the sample weighted constructor of a base estimator, or nil when the estimator
+// does not support sample weights.
+func weightedFactoryConstructor(baseModel string) func(x [][]float64, y []float64, weights []float64) Ensemble.Estimator {
+	switch baseModel {
+	case "linreg":
+		return LinReg.NewWeightedLinReg
+	case "ols":
+		return OLS.NewWLS
+	}
+	return nil
+}
+
+// BaseEstimatorSpec describes one base estimator of a stacked or voting ensemble. Setting Ensemble wraps the base
+// estimator in a bagged, boosted or adaboost ensemble of NEstimators copies.
+type BaseEstimatorSpec struct {
+	BaseEstimator       string                 `json:"base_estimator"`
+	BaseEstimatorParams map[string]interface{} `json:"base_estimator_params"`
+	Ensemble            string                 `json:"ensemble,omitempty"`
+	NEstimators         int                    `json:"n_estimators,omitempty"`
+	RandomSeed          int64                  `json:"random_seed,omitempty"`
+}
+
+// specFactory returns the factory of the estimator described by spec.
+func specFactory(spec BaseEstimatorSpec) (func(x [][]float64, y []float64) Ensemble.Estimator, error) {
+	if slices.Contains(classifiers, spec.BaseEstimator) {
+		return nil, errors.New("stacked and voting ensembles need regression base estimators")
+	}
+	baseEstimatorFactory, err := ensembleFactoryConstructor(spec.BaseEstimator, spec.BaseEstimatorParams)
+	if err != nil {
+		return nil, err
+	}
+	nEstimators := spec.NEstimators
+	if nEstimators == 0 {
+		nEstimators = 10
+	}
+	randomSeed := spec.RandomSeed
+
+	switch spec.Ensemble {
+	case "":
+		return baseEstimatorFactory, nil
+	case "bagged":
+		return func(x [][]float64, y []float64) Ensemble.Estimator {
+			return Ensemble.NewBagged(baseEstimatorFactory, nEstimators, x, y, &randomSeed)
+		}, nil
+	case "boosted":
+		return func(x [][]float64, y []float64) Ensemble.Estimator {
+			return Ensemble.NewStochasticBoosted(baseEstimatorFactory, nEstimators, x, y, 0.1, 1, 1, &randomSeed)
+		}, nil
+	case "adaboost":
+		weightedFactory := weightedFactoryConstructor(spec.BaseEstimator)
+		return func(x [][]float64, y []float64) Ensemble.Estimator {
+			ensemble := Ensemble.NewAdaBoost(baseEstimatorFactory, nEstimators, x, y, 1.0, Ensemble.AdaBoostLinear, &randomSeed).(*Ensemble.AdaBoost)
+			ensemble.WeightedFactory = weightedFactory
+			return ensemble
+		}, nil
+	}
+	return nil, errors.New("unsupported ensemble")
+}
+
 type AbstractPostBody struct {
 	X [][]float64 `json:"X"`
 	Y []float64   `json:"Y"`
 }
 
+type LeastSquaresPostBody struct {
+	AbstractPostBody
+	Weights []float64 `json:"weights,omitempty"` // per-row sample weights (WLS)
+}
+
+type GLSPostBody struct {
+	AbstractPostBody
+	Rho   *float64    `json:"rho,omitempty"`   // AR(1) autocorrelation of the residuals
+	Sigma [][]float64 `json:"sigma,omitempty"` // full residual covariance, takes precedence over rho
+}
+
+type ForestPostBody struct {
+	AbstractPostBody
+	NEstimators     *int   `json:"n_estimators,omitempty"`
+	MaxDepth        *int   `json:"max_depth,omitempty"`
+	MinSamplesSplit *int   `json:"min_samples_split,omitempty"`
+	MinSamplesLeaf  *int   `json:"min_samples_leaf,omitempty"`
+	MaxFeatures     int    `json:"max_features,omitempty"` // 0 uses the estimator default
+	Criterion       string `json:"criterion,omitempty"`
+	RandomSeed      *int64 `json:"random_seed,omitempty"`
+	NJobs           int    `json:"n_jobs,omitempty"`
+	PredictionPostBody
+}
+
+// PredictionPostBody holds rows to predict with uncertainty after fitting a bagged ensemble or a forest.
+type PredictionPostBody struct {
+	XPredict      [][]float64 `json:"X_predict,omitempty"`
+	IntervalAlpha *float64    `json:"interval_alpha,omitempty"` // miscoverage of the intervals, default 0.1
+}
+
 type DecTreePostBody struct {
 	AbstractPostBody
 	MaxDepth        int   `json:"max_depth"`
@@ -47,6 +448,129 @@ type DecTreePostBody struct {
 	MinSamplesLeaf  int   `json:"min_samples_leaf"`
 	MaxFeatures     int   `json:"max_features"`
 	RandomSeed      int64 `json:"random_seed"`
+
+	Criterion string    `json:"criterion,omitempty"` // split criterion, squared_error by default
+	Quantile  *float64  `json:"quantile,omitempty"`  // switches the tree to quantile-leaf mode
+	Quantiles []float64 `json:"quantiles,omitempty"` // extra quantiles to predict for every row of X
+}
+
+type DecTreeClassifierPostBody struct {
+	AbstractPostBody
+	MaxDepth        int    `json:"max_depth"`
+	MinSamplesSplit int    `json:"min_samples_split"`
+	MinSamplesLeaf  int    `json:"min_samples_leaf"`
+	MaxFeatures     int    `json:"max_features"`
+	RandomSeed      int64  `json:"random_seed"`
+	Criterion       string `json:"criterion,omitempty"`
+}
+
+type RidgePostBody struct {
+	AbstractPostBody
+	Alpha        *float64 `json:"alpha,omitempty"`
+	FitIntercept *bool    `json:"fit_intercept,omitempty"`
+}
+
+type ElasticNetPostBody struct {
+	AbstractPostBody
+	Alpha        *float64 `json:"alpha,omitempty"`
+	L1Ratio      *float64 `json:"l1_ratio,omitempty"` // ignored by lasso
+	MaxIter      *int     `json:"max_iter,omitempty"`
+	Tol          *float64 `json:"tol,omitempty"`
+	FitIntercept *bool    `json:"fit_intercept,omitempty"`
+}
+
+type CVPostBody struct {
+	AbstractPostBody
+	NAlphas      *int     `json:"n_alphas,omitempty"`
+	NFolds       *int     `json:"n_folds,omitempty"`
+	L1Ratio      *float64 `json:"l1_ratio,omitempty"` // elasticnetcv only
+	MaxIter      *int     `json:"max_iter,omitempty"` // lassocv and elasticnetcv only
+	Tol          *float64 `json:"tol,omitempty"`      // lassocv and elasticnetcv only
+	FitIntercept *bool    `json:"fit_intercept,omitempty"`
+	RandomSeed   *int64   `json:"random_seed,omitempty"`
+}
+
+type DiagnosticsPostBody struct {
+	AbstractPostBody
+	Model        string    `json:"model,omitempty"`         // 'ols' (default) | 'linreg'
+	Residuals    []float64 `json:"residuals,omitempty"`     // skips fitting when provided
+	FitIntercept *bool     `json:"fit_intercept,omitempty"` // only used with residuals
+}
+
+type HuberPostBody struct {
+	AbstractPostBody
+	Epsilon *float64 `json:"epsilon,omitempty"`
+	MaxIter *int     `json:"max_iter,omitempty"`
+	Tol     *float64 `json:"tol,omitempty"`
+}
+
+type QuantRegPostBody struct {
+	AbstractPostBody
+	Quantile *float64 `json:"quantile,omitempty"`
+	MaxIter  *int     `json:"max_iter,omitempty"`
+	Tol      *float64 `json:"tol,omitempty"`
+}
+
+type GLMPostBody struct {
+	AbstractPostBody
+	Family  string   `json:"family"`
+	Link    string   `json:"link,omitempty"`
+	Power   *float64 `json:"power,omitempty"` // tweedie only
+	MaxIter *int     `json:"max_iter,omitempty"`
+	Tol     *float64 `json:"tol,omitempty"`
+}
+
+type HistGBPostBody struct {
+	AbstractPostBody
+	LearningRate       *float64 `json:"learning_rate,omitempty"`
+	MaxIter            *int     `json:"max_iter,omitempty"`
+	MaxLeafNodes       *int     `json:"max_leaf_nodes,omitempty"`
+	MinSamplesLeaf     *int     `json:"min_samples_leaf,omitempty"`
+	L2Regularization   *float64 `json:"l2_regularization,omitempty"`
+	MaxBins            *int     `json:"max_bins,omitempty"`
+	ValidationFraction *float64 `json:"validation_fraction,omitempty"` // 0 disables early stopping
+	NIterNoChange      *int     `json:"n_iter_no_change,omitempty"`
+	Tol                *float64 `json:"tol,omitempty"`
+	RandomSeed         *int64   `json:"random_seed,omitempty"`
+}
+
+type LogRegPostBody struct {
+	AbstractPostBody
+	Alpha        *float64 `json:"alpha,omitempty"`
+	FitIntercept *bool    `json:"fit_intercept,omitempty"`
+	MaxIter      *int     `json:"max_iter,omitempty"`
+	Tol          *float64 `json:"tol,omitempty"`
+}
+
+type RANSACPostBody struct {
+	AbstractPostBody
+	BaseEstimator       string                 `json:"base_estimator"`
+	BaseEstimatorParams map[string]interface{} `json:"base_estimator_params"`
+	MinSamples          int                    `json:"min_samples,omitempty"`
+	ResidualThreshold   float64                `json:"residual_threshold,omitempty"`
+	MaxTrials           *int                   `json:"max_trials,omitempty"`
+	RandomSeed          *int64                 `json:"random_seed,omitempty"`
+}
+
+type ConformalPostBody struct {
+	AbstractPostBody
+	BaseEstimator             string                 `json:"base_estimator"`
+	BaseEstimatorParams       map[string]interface{} `json:"base_estimator_params"`
+	Method                    string                 `json:"method,omitempty"`
+	Alpha                     *float64               `json:"alpha,omitempty"`
+	CalibrationFraction       *float64               `json:"calibration_fraction,omitempty"` // split only
+	NFolds                    *int                   `json:"n_folds,omitempty"`              // cv+ only
+	DifficultyEstimator       string                 `json:"difficulty_estimator,omitempty"` // normalized intervals
+	DifficultyEstimatorParams map[string]interface{} `json:"difficulty_estimator_params,omitempty"`
+	RandomSeed                *int64                 `json:"random_seed,omitempty"`
+	XPredict                  [][]float64            `json:"X_predict,omitempty"`
+}
+
+type TheilSenPostBody struct {
+	AbstractPostBody
+	NSubsamples      int    `json:"n_subsamples,omitempty"`
+	MaxSubpopulation *int   `json:"max_subpopulation,omitempty"`
+	RandomSeed       *int64 `json:"random_seed,omitempty"`
 }
 
 type EnsemblePostBody struct {
@@ -54,8 +578,28 @@ type EnsemblePostBody struct {
 	BaseEstimator       string                 `json:"base_estimator"`
 	BaseEstimatorParams map[string]interface{} `json:"base_estimator_params"`
 	NEstimators         int                    `json:"n_estimators"`
-	RandomSeed          int64                  `json:"random_seed,omitempty"`   // for bagged
-	LearningRate        float64                `json:"learning_rate,omitempty"` // for boosted
+	RandomSeed          int64                  `json:"random_seed,omitempty"`   // for bagged and boosted subsampling
+	LearningRate        float64                `json:"learning_rate,omitempty"` // for boosted and adaboost
+	Loss                string                 `json:"loss,omitempty"`          // for boosted and adaboost
+	Alpha               *float64               `json:"alpha,omitempty"`         // for boosted huber and quantile losses
+	Subsample           *float64               `json:"subsample,omitempty"`     // for boosted
+	ColsampleByTree     *float64               `json:"colsample_bytree,omitempty"`
+	XValidation         [][]float64            `json:"X_validation,omitempty"`        // for boosted early stopping
+	YValidation         []float64              `json:"Y_validation,omitempty"`        // for boosted early stopping
+	ValidationFraction  *float64               `json:"validation_fraction,omitempty"` // for boosted, ignored with X_validation
+	NIterNoChange       *int                   `json:"n_iter_no_change,omitempty"`    // for boosted, 0 disables early stopping
+	Tol                 *float64               `json:"tol,omitempty"`                 // for boosted
+	BaseEstimators      []BaseEstimatorSpec    `json:"base_estimators,omitempty"`     // for stacked and voting, instead of base_estimator
+	Weights             []float64              `json:"weights,omitempty"`             // for voting
+	MetaEstimator       string                 `json:"meta_estimator,omitempty"`      // for stacked
+	MetaEstimatorParams map[string]interface{} `json:"meta_estimator_params,omitempty"`
+	NFolds              int                    `json:"n_folds,omitempty"`            // for stacked
+	NJobs               int                    `json:"n_jobs,omitempty"`             // for bagged
+	MaxSamples          *float64               `json:"max_samples,omitempty"`        // for bagged
+	Bootstrap           *bool                  `json:"bootstrap,omitempty"`          // for bagged
+	MaxFeatures         *float64               `json:"max_features,omitempty"`       // for bagged
+	BootstrapFeatures   bool                   `json:"bootstrap_features,omitempty"` // for bagged
+	PredictionPostBody                         // for bagged
 }
 
 // Documentation as JSON response for each endpoint
@@ -73,199 +617,1853 @@ var defaultBody = map[string]interface{}{
 	"Y": "[target]",
 }
 
-var ensembleMethods = []string{"bagged", "boosted"}
+var ensembleMethods = []string{"bagged", "boosted", "adaboost", "stacked", "voting"}
+
+var classifiers = []string{"logistic", "dectreeclassifier"}
+
+var models = []string{"linreg", "ols", "dectree", "ridge", "lasso", "elasticnet", "huber", "quantreg", "glm", "histgb", "randomforest", "extratrees"}
+
+var inferenceDescription = map[string]string{
+	"std_errors":     "Standard error of each parameter ([intercept, coefs...] for models with an intercept).",
+	"t_values":       "t-statistic of each parameter.",
+	"p_values":       "Two-sided p-value of each t-statistic.",
+	"conf_int":       "95% confidence interval [lower, upper] of each parameter.",
+	"n_obs":          "Number of observations.",
+	"df_model":       "Model degrees of freedom.",
+	"df_resid":       "Residual degrees of freedom.",
+	"r2":             "R-squared (uncentered for models without an intercept).",
+	"adj_r2":         "Adjusted R-squared.",
+	"f_statistic":    "F-statistic of the joint significance of all features.",
+	"f_p_value":      "p-value of the F-statistic.",
+	"log_likelihood": "Gaussian log-likelihood of the fit.",
+	"aic":            "Akaike information criterion.",
+	"bic":            "Bayesian information criterion.",
+}
+
+var weightsParam = map[string][]string{
+	"weights": {"[float]", "Optional positive per-row sample weights, fits weighted least squares. Fit metrics are weighted too."},
+}
 
-var models = []string{"linreg", "ols", "dectree"}
+var weightedBody = map[string]interface{}{
+	"X":       "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+	"Y":       "[target]",
+	"weights": "[w1, w2, ...] // optional",
+}
 
 var linRegDocs = map[string]interface{}{
-	"description":      "Basic linear regression with no configurable arguments.",
-	"params":           []string{},
+	"description":      "Basic linear regression without an intercept. Accepts optional sample weights.",
+	"params":           weightsParam,
 	"ensemble_support": true,
 	"ensemble_methods": ensembleMethods,
 	"request_format": map[string]interface{}{
 		"type": "POST",
-		"body": defaultBody,
+		"body": weightedBody,
 		"response": map[string]interface{}{
 			"coefficients": "[coef1, coef2, ...]",
-			"intercept":    "intercept",
 			"fit_metrics":  metricsDescription,
+			"inference":    inferenceDescription,
+			"summary":      "string // text table of the inference",
 		},
 	},
 }
 
 var olsDocs = map[string]interface{}{
-	"description":      "Ordinary Least Squares regression with no configurable arguments.",
-	"params":           []string{},
+	"description":      "Ordinary Least Squares regression. Accepts optional sample weights (WLS).",
+	"params":           weightsParam,
 	"ensemble_support": true,
 	"ensemble_methods": ensembleMethods,
 	"request_format": map[string]interface{}{
 		"type": "POST",
-		"body": defaultBody,
+		"body": weightedBody,
 	},
 	"response": map[string]interface{}{
 		"coefficients": "[coef1, coef2, ...]",
 		"intercept":    "intercept",
 		"fit_metrics":  metricsDescription,
+		"inference":    inferenceDescription,
+		"summary":      "string // text table of the inference",
 	},
 }
 
-var decTreeDocs = map[string]interface{}{
-	"description": "Decision Tree regression with configurable arguments.",
+var glsDocs = map[string]interface{}{
+	"description": "Generalized Least Squares regression with an intercept, for residuals correlated through a known covariance structure. Rows must be in time order for AR(1).",
 	"params": map[string][]string{
-		"max_depth":         {"int", "Maximum depth of the tree. Default is 5."},
-		"min_samples_split": {"int", "Minimum number of samples required to split an internal node. Default is 2."},
-		"min_samples_leaf":  {"int", "Minimum number of samples required to be at a leaf node. Default is 1."},
-		"max_features":      {"int", "Number of features to consider when looking for the best split. Default is all features."},
-		"random_seed":       {"int", "Random seed for reproducibility. Default is current unix time in nanoseconds."},
+		"rho":   {"float", "AR(1) autocorrelation of the residuals in (-1, 1). Default is estimated from OLS residuals (feasible GLS)."},
+		"sigma": {"[[float]]", "Full n x n residual covariance matrix. Takes precedence over rho."},
 	},
-	"ensemble_support": true,
-	"ensemble_methods": ensembleMethods,
+	"ensemble_support": false,
 	"request_format": map[string]interface{}{
 		"type": "POST",
 		"body": map[string]string{
-			"X":                 "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
-			"Y":                 "[target]",
-			"max_depth":         "int",
-			"min_samples_split": "int",
-			"min_samples_leaf":  "int",
-			"max_features":      "int",
-			"random_seed":       "int",
+			"X":     "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":     "[target]",
+			"rho":   "float // optional",
+			"sigma": "[[float]] // optional",
 		},
 		"response": map[string]interface{}{
-			"tree_structure":     "{...}",
-			"feature_importance": "[imp1, imp2, ...]",
-			"fit_metrics":        metricsDescription,
+			"coefficients": "[coef1, coef2, ...]",
+			"intercept":    "intercept",
+			"rho":          "float // AR(1) only",
+			"fit_metrics":  metricsDescription,
+			"inference":    inferenceDescription,
+			"summary":      "string // text table of the inference",
 		},
 	},
 }
 
-var baggedDocs = map[string]interface{}{
-	"description": "Bagging ensemble method. Combines the predictions of multiple base estimators trained on random subsets of the data.",
+var ridgeDocs = map[string]interface{}{
+	"description": "Ridge regression (L2 penalized least squares). Stable under collinear features where OLS coefficients blow up.",
 	"params": map[string][]string{
-		"n_estimators": {"int", "The number of base estimators in the ensemble. Default is 10."},
-		"random_seed":  {"int", "Random seed for reproducibility. Default is current unix time in nanoseconds."},
+		"alpha":         {"float", "L2 penalty strength, must be >= 0. Default is 1.0."},
+		"fit_intercept": {"bool", "Whether to fit an unpenalized intercept term. Default is true."},
 	},
-	"supported_base_estimators": models,
+	"ensemble_support": true,
+	"ensemble_methods": ensembleMethods,
 	"request_format": map[string]interface{}{
 		"type": "POST",
 		"body": map[string]string{
-			"X":                     "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
-			"Y":                     "[target]",
-			"base_estimator":        "'linreg' | 'ols' | 'dectree'",
-			"base_estimator_params": "{...} // {} if no params",
-			"n_estimators":          "int",
-			"random_seed":           "int",
+			"X":             "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":             "[target]",
+			"alpha":         "float",
+			"fit_intercept": "bool",
 		},
 		"response": map[string]interface{}{
-			"base_estimator_fit_metrics": "[{...}, {...}, ...]",
-			"fit_metrics":                metricsDescription,
+			"coefficients": "[coef1, coef2, ...]",
+			"intercept":    "intercept",
+			"alpha":        "alpha",
+			"fit_metrics":  metricsDescription,
 		},
 	},
 }
 
-var boostedDocs = map[string]interface{}{
-	"description": "Boosting ensemble method. Combines the predictions of multiple base estimators trained sequentially, where each estimator tries to correct the errors of the previous one.",
+var sparseResponse = map[string]interface{}{
+	"coefficients":      "[coef1, coef2, ...]",
+	"intercept":         "intercept",
+	"zero_coefficients": "[index1, index2, ...] // features driven to zero",
+	"n_iter":            "int",
+	"converged":         "bool",
+	"fit_metrics":       metricsDescription,
+}
+
+var lassoDocs = map[string]interface{}{
+	"description": "Lasso regression (L1 penalized least squares) fitted with coordinate descent. Drives uninformative coefficients to exactly zero.",
 	"params": map[string][]string{
-		"n_estimators":  {"int", "The number of base estimators in the ensemble. Default is 10."},
-		"learning_rate": {"float", "Learning rate shrinks the contribution of each base estimator. Default is 0.1."},
+		"alpha":         {"float", "L1 penalty strength, must be >= 0. Default is 1.0."},
+		"max_iter":      {"int", "Maximum number of coordinate descent sweeps. Default is 1000."},
+		"tol":           {"float", "Stop when the largest relative coefficient update falls below tol. Default is 1e-4."},
+		"fit_intercept": {"bool", "Whether to fit an unpenalized intercept term. Default is true."},
 	},
-	"supported_base_estimators": models,
+	"ensemble_support": true,
+	"ensemble_methods": ensembleMethods,
 	"request_format": map[string]interface{}{
 		"type": "POST",
 		"body": map[string]string{
-			"X":                     "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
-			"Y":                     "[target]",
-			"base_estimator":        "'linreg' | 'ols' | 'dectree'",
-			"base_estimator_params": "{...} // {} if no params",
-			"n_estimators":          "int",
-			"learning_rate":         "float",
-		},
-		"response": map[string]interface{}{
-			"base_estimator_fit_response": "[{...}, {...}, ...]",
-			"fit_metrics":                 metricsDescription,
+			"X":             "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":             "[target]",
+			"alpha":         "float",
+			"max_iter":      "int",
+			"tol":           "float",
+			"fit_intercept": "bool",
 		},
+		"response": sparseResponse,
 	},
 }
 
-var endpointUsage = map[string]interface{}{
-	"estimators": map[string]interface{}{
-		"/linreg":  linRegDocs,
-		"/ols":     olsDocs,
-		"/dectree": decTreeDocs,
+var elasticNetDocs = map[string]interface{}{
+	"description": "ElasticNet regression (mixed L1/L2 penalty) fitted with coordinate descent.",
+	"params": map[string][]string{
+		"alpha":         {"float", "Overall penalty strength, must be >= 0. Default is 1.0."},
+		"l1_ratio":      {"float", "Share of the L1 penalty in [0, 1]; 1 is lasso, 0 is ridge. Default is 0.5."},
+		"max_iter":      {"int", "Maximum number of coordinate descent sweeps. Default is 1000."},
+		"tol":           {"float", "Stop when the largest relative coefficient update falls below tol. Default is 1e-4."},
+		"fit_intercept": {"bool", "Whether to fit an unpenalized intercept term. Default is true."},
 	},
-	"ensembles": map[string]interface{}{
-		"/bagged":  baggedDocs,
-		"/boosted": boostedDocs,
+	"ensemble_support": true,
+	"ensemble_methods": ensembleMethods,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":             "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":             "[target]",
+			"alpha":         "float",
+			"l1_ratio":      "float",
+			"max_iter":      "int",
+			"tol":           "float",
+			"fit_intercept": "bool",
+		},
+		"response": sparseResponse,
 	},
 }
 
-// GET handlers for each endpoint to return the documentation as JSON
+var pathDescription = "[{alpha, coefs, intercept, validation_mse, validation_std}, ...] // one entry per alpha of the grid"
 
-func ModelsHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Only GET is allowed on the /models endpoint", http.StatusMethodNotAllowed)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(endpointUsage)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-	return
+var ridgeCVDocs = map[string]interface{}{
+	"description": "Ridge regression with the alpha chosen by k-fold cross-validation over a log-spaced grid in [1e-3, 1e3].",
+	"params": map[string][]string{
+		"n_alphas":      {"int", "Number of alphas in the grid. Default is 50."},
+		"n_folds":       {"int", "Number of cross-validation folds. Default is 5."},
+		"fit_intercept": {"bool", "Whether to fit an unpenalized intercept term. Default is true."},
+		"random_seed":   {"int", "Random seed for the fold shuffle. Default is current unix time in nanoseconds."},
+	},
+	"ensemble_support": false,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":             "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":             "[target]",
+			"n_alphas":      "int",
+			"n_folds":       "int",
+			"fit_intercept": "bool",
+			"random_seed":   "int",
+		},
+		"response": map[string]interface{}{
+			"coefficients": "[coef1, coef2, ...] // at best_alpha",
+			"intercept":    "intercept",
+			"best_alpha":   "float",
+			"path":         pathDescription,
+			"fit_metrics":  metricsDescription,
+		},
+	},
 }
 
-func EstimatorsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(endpointUsage["estimators"])
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-	return
+var lassoCVDocs = map[string]interface{}{
+	"description": "Lasso regression with the alpha chosen by k-fold cross-validation. The grid is log-spaced from the smallest alpha that zeroes every coefficient down to 1e-3 of it.",
+	"params": map[string][]string{
+		"n_alphas":      {"int", "Number of alphas in the grid. Default is 100."},
+		"n_folds":       {"int", "Number of cross-validation folds. Default is 5."},
+		"max_iter":      {"int", "Maximum number of coordinate descent sweeps per alpha. Default is 1000."},
+		"tol":           {"float", "Coordinate descent tolerance. Default is 1e-4."},
+		"fit_intercept": {"bool", "Whether to fit an unpenalized intercept term. Default is true."},
+		"random_seed":   {"int", "Random seed for the fold shuffle. Default is current unix time in nanoseconds."},
+	},
+	"ensemble_support": false,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":             "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":             "[target]",
+			"n_alphas":      "int",
+			"n_folds":       "int",
+			"max_iter":      "int",
+			"tol":           "float",
+			"fit_intercept": "bool",
+			"random_seed":   "int",
+		},
+		"response": map[string]interface{}{
+			"coefficients":      "[coef1, coef2, ...] // at best_alpha",
+			"intercept":         "intercept",
+			"zero_coefficients": "[index1, index2, ...] // features driven to zero at best_alpha",
+			"best_alpha":        "float",
+			"path":              pathDescription,
+			"fit_metrics":       metricsDescription,
+		},
+	},
 }
 
-func LinRegGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(linRegDocs)
+var elasticNetCVDocs = map[string]interface{}{
+	"description": "ElasticNet regression with the alpha chosen by k-fold cross-validation for a fixed l1_ratio.",
+	"params": map[string][]string{
+		"l1_ratio":      {"float", "Share of the L1 penalty in (0, 1]. Default is 0.5."},
+		"n_alphas":      {"int", "Number of alphas in the grid. Default is 100."},
+		"n_folds":       {"int", "Number of cross-validation folds. Default is 5."},
+		"max_iter":      {"int", "Maximum number of coordinate descent sweeps per alpha. Default is 1000."},
+		"tol":           {"float", "Coordinate descent tolerance. Default is 1e-4."},
+		"fit_intercept": {"bool", "Whether to fit an unpenalized intercept term. Default is true."},
+		"random_seed":   {"int", "Random seed for the fold shuffle. Default is current unix time in nanoseconds."},
+	},
+	"ensemble_support": false,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":             "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":             "[target]",
+			"l1_ratio":      "float",
+			"n_alphas":      "int",
+			"n_folds":       "int",
+			"max_iter":      "int",
+			"tol":           "float",
+			"fit_intercept": "bool",
+			"random_seed":   "int",
+		},
+		"response": lassoCVDocs["request_format"].(map[string]interface{})["response"],
+	},
+}
+
+var huberDocs = map[string]interface{}{
+	"description": "Huber regression with an intercept, fitted with iteratively reweighted least squares. Residuals beyond epsilon robust standard deviations are down-weighted instead of squared.",
+	"params": map[string][]string{
+		"epsilon":  {"float", "Residual threshold (in robust standard deviations) where the loss turns linear, >= 1. Default is 1.35."},
+		"max_iter": {"int", "Maximum number of IRLS iterations. Default is 100."},
+		"tol":      {"float", "Stop when the largest coefficient update falls below tol. Default is 1e-5."},
+	},
+	"ensemble_support": true,
+	"ensemble_methods": ensembleMethods,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":        "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":        "[target]",
+			"epsilon":  "float",
+			"max_iter": "int",
+			"tol":      "float",
+		},
+		"response": map[string]interface{}{
+			"coefficients": "[coef1, coef2, ...]",
+			"intercept":    "intercept",
+			"weights":      "[w1, w2, ...] // final IRLS weights, < 1 for down-weighted rows",
+			"outlier_mask": "[bool, ...]",
+			"scale":        "float // robust residual standard deviation",
+			"n_iter":       "int",
+			"converged":    "bool",
+			"fit_metrics":  metricsDescription,
+		},
+	},
+}
+
+var quantRegDocs = map[string]interface{}{
+	"description": "Linear quantile regression with an intercept. Predicts a conditional quantile of Y by minimizing the pinball loss with iteratively reweighted least squares; quantile 0.5 is least absolute deviations.",
+	"params": map[string][]string{
+		"quantile": {"float", "Quantile to predict, in (0, 1). Default is 0.5."},
+		"max_iter": {"int", "Maximum number of IRLS iterations. Default is 500."},
+		"tol":      {"float", "Stop when the largest coefficient update falls below tol. Default is 1e-6."},
+	},
+	"ensemble_support": true,
+	"ensemble_methods": ensembleMethods,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":        "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":        "[target]",
+			"quantile": "float",
+			"max_iter": "int",
+			"tol":      "float",
+		},
+		"response": map[string]interface{}{
+			"coefficients": "[coef1, coef2, ...]",
+			"intercept":    "intercept",
+			"pinball_loss": "float",
+			"coverage":     "float // share of rows at or below their prediction",
+			"n_iter":       "int",
+			"converged":    "bool",
+			"fit_metrics":  metricsDescription,
+		},
+	},
+}
+
+// forestDocs documents the RandomForest and ExtraTrees endpoints, which share their params.
+func forestDocs(description, maxFeaturesDefault string) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"params": map[string][]string{
+			"n_estimators":      {"int", "Number of trees. Default is 100."},
+			"max_depth":         {"int", "Maximum depth of each tree. Default is 10."},
+			"min_samples_split": {"int", "Minimum number of samples required to split an internal node. Default is 2."},
+			"min_samples_leaf":  {"int", "Minimum number of samples required to be at a leaf node. Default is 1."},
+			"max_features":      {"int", "Number of features considered at each split. Default is " + maxFeaturesDefault + "."},
+			"criterion":         {"string", "'squared_error' | 'absolute_error' | 'friedman_mse' | 'poisson'. Split criterion of every tree. Default is squared_error."},
+			"random_seed":       {"int", "Random seed of the bootstrap samples and of the trees. Default is current unix time in nanoseconds."},
+			"n_jobs":            {"int", "Number of goroutines fitting the trees, -1 uses every CPU. Results do not depend on it. Default is 1."},
+			"X_predict":         {"[[float]]", "Rows to predict after fitting, with the spread of the trees and jackknife+-after-bootstrap prediction intervals. Optional."},
+			"interval_alpha":    {"float", "Miscoverage of the intervals in (0, 1), 0.1 gives 90% intervals. Default is 0.1."},
+		},
+		"ensemble_support": true,
+		"ensemble_methods": ensembleMethods,
+		"request_format": map[string]interface{}{
+			"type": "POST",
+			"body": map[string]string{
+				"X":                 "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+				"Y":                 "[target]",
+				"n_estimators":      "int",
+				"max_depth":         "int",
+				"min_samples_split": "int",
+				"min_samples_leaf":  "int",
+				"max_features":      "int",
+				"criterion":         "string",
+				"random_seed":       "int",
+				"n_jobs":            "int",
+				"X_predict":         "[[feature1, feature2, ...], ...] // optional",
+				"interval_alpha":    "float",
+			},
+			"response": map[string]interface{}{
+				"max_features":        "int // features considered at each split",
+				"feature_importances": "[imp1, imp2, ...] // mean impurity decrease of each feature over the trees, sums to 1",
+				"oob_score":           "float // R-squared of the out-of-bag predictions",
+				"oob_predictions":     "[pred1, pred2, ...] // each row predicted by the trees whose bootstrap sample left it out, null if every sample drew it",
+				"oob_metrics":         "metrics of the out-of-bag predictions",
+				"fit_metrics":         metricsDescription,
+				"predictions":         predictionsDescription,
+			},
+		},
+	}
+}
+
+var randomForestDocs = forestDocs("Random forest regression: decision trees fitted on bootstrap samples, each choosing every split among a random subset of the features, with predictions averaged.", "a third of the features")
+
+var extraTreesDocs = forestDocs("Extra-trees (extremely randomized trees) regression: like a random forest, but every candidate feature is split at a uniformly random threshold instead of its best one, which further decorrelates the trees. The trees are still fitted on bootstrap samples, keeping the out-of-bag metrics and intervals.", "the square root of the number of features")
+
+var histGBDocs = map[string]interface{}{
+	"description": "Histogram-based gradient boosting of regression trees for squared error (LightGBM style). Features are binned into at most max_bins quantile buckets once, and each tree is grown best-first from per-bin gradient and hessian sums. Suited to large tabular datasets.",
+	"params": map[string][]string{
+		"learning_rate":       {"float", "Shrinkage applied to every tree. Default is 0.1."},
+		"max_iter":            {"int", "Maximum number of trees. Default is 100."},
+		"max_leaf_nodes":      {"int", "Maximum number of leaves per tree. Default is 31."},
+		"min_samples_leaf":    {"int", "Minimum number of rows per leaf. Default is 20."},
+		"l2_regularization":   {"float", "L2 penalty on the leaf values, leaf = -sum(grad) / (sum(hess) + l2). Default is 0."},
+		"max_bins":            {"int", "Maximum number of bins per feature, at most 255. Default is 255."},
+		"validation_fraction": {"float", "Fraction of rows held out for early stopping, 0 disables it. Default is 0.1."},
+		"n_iter_no_change":    {"int", "Stop once the validation loss has not improved for this many trees, 0 disables early stopping. Default is 10."},
+		"tol":                 {"float", "Minimum improvement of the validation loss. Default is 1e-7."},
+		"random_seed":         {"int", "Seed of the validation split. Default is current unix time in nanoseconds."},
+	},
+	"ensemble_support": true,
+	"ensemble_methods": ensembleMethods,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                   "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                   "[target]",
+			"learning_rate":       "float",
+			"max_iter":            "int",
+			"max_leaf_nodes":      "int",
+			"min_samples_leaf":    "int",
+			"l2_regularization":   "float",
+			"max_bins":            "int",
+			"validation_fraction": "float",
+			"n_iter_no_change":    "int",
+			"tol":                 "float",
+			"random_seed":         "int",
+		},
+		"response": map[string]interface{}{
+			"baseline":        "float // initial prediction, the mean of the training targets",
+			"n_iter":          "int // number of trees fitted",
+			"train_loss":      "[mse1, mse2, ...] // after each tree",
+			"validation_loss": "[mse1, mse2, ...] // after each tree, empty without early stopping",
+			"fit_metrics":     metricsDescription,
+		},
+	},
+}
+
+var glmDocs = map[string]interface{}{
+	"description": "Generalized linear model with an intercept, fitted with iteratively reweighted least squares. Models a link of the target mean as linear in the features, with the target variance a function of its mean set by the family.",
+	"params": map[string][]string{
+		"family":   {"string", "'gaussian' | 'poisson' | 'gamma' | 'tweedie'. Poisson suits counts (Y >= 0), gamma positive skewed targets (Y > 0) and tweedie non-negative targets with exact zeros. Default is gaussian."},
+		"link":     {"string", "'identity' | 'log'. Default is identity for gaussian and log for the other families."},
+		"power":    {"float", "Tweedie variance power, V(mu) = mu^power. Values in (1, 2) give the compound Poisson-Gamma distribution. Default is 1.5."},
+		"max_iter": {"int", "Maximum number of IRLS iterations. Default is 100."},
+		"tol":      {"float", "Stop when the relative change in deviance falls below tol. Default is 1e-8."},
+	},
+	"ensemble_support": true,
+	"ensemble_methods": ensembleMethods,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":        "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":        "[target]",
+			"family":   "string",
+			"link":     "string",
+			"power":    "float",
+			"max_iter": "int",
+			"tol":      "float",
+		},
+		"response": map[string]interface{}{
+			"coefficients":   "[coef1, coef2, ...] // on the scale of the link",
+			"intercept":      "intercept",
+			"family":         "string",
+			"link":           "string",
+			"deviance":       "float",
+			"null_deviance":  "float // deviance of the intercept-only model",
+			"d2":             "float // fraction of deviance explained",
+			"scale":          "float // estimated dispersion, 1 for poisson",
+			"log_likelihood": "float | null",
+			"aic":            "float | null // null when the likelihood has no closed form (tweedie power outside [1, 2])",
+			"n_iter":         "int",
+			"converged":      "bool",
+			"fit_metrics":    metricsDescription,
+		},
+	},
+}
+
+var classificationMetricsDescription = map[string]string{
+	"accuracy":         "float",
+	"precision":        "float // of the larger label when binary, macro average otherwise",
+	"recall":           "float",
+	"f1":               "float",
+	"log_loss":         "float",
+	"roc_auc":          "float // one-vs-rest macro average with more than two classes",
+	"classes":          "[class1, class2, ...]",
+	"confusion_matrix": "[[count, ...], ...] // rows are true classes, columns predicted classes",
+}
+
+var logRegDocs = map[string]interface{}{
+	"description": "L2 regularized logistic regression. Y holds class labels; two classes fit a binary model and more fit a multinomial (softmax) model.",
+	"task":        "classification",
+	"params": map[string][]string{
+		"alpha":         {"float", "L2 penalty strength on the coefficients (1 / C). Default is 1.0."},
+		"fit_intercept": {"bool", "Whether to fit (unpenalized) intercepts. Default is true."},
+		"max_iter":      {"int", "Maximum number of L-BFGS iterations. Default is 500."},
+		"tol":           {"float", "Stop when the largest gradient entry of the mean loss falls below tol. Default is 1e-6."},
+	},
+	"ensemble_support": true,
+	"ensemble_methods": []string{"bagged"},
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":             "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":             "[class label]",
+			"alpha":         "float",
+			"fit_intercept": "bool",
+			"max_iter":      "int",
+			"tol":           "float",
+		},
+		"response": map[string]interface{}{
+			"classes":      "[class1, class2, ...]",
+			"coefficients": "[[coef1, coef2, ...], ...] // one row per class, a single row for the larger label when binary",
+			"intercepts":   "[intercept, ...]",
+			"multinomial":  "bool",
+			"n_iter":       "int",
+			"converged":    "bool",
+			"fit_metrics":  classificationMetricsDescription,
+		},
+	},
+}
+
+var decTreeClassifierDocs = map[string]interface{}{
+	"description": "Decision Tree classification. Splits minimize the Gini impurity or entropy of the class labels and leaves hold class distributions. Bag it for a random forest classifier.",
+	"task":        "classification",
+	"params": map[string][]string{
+		"max_depth":         {"int", "Maximum depth of the tree. Default is 10."},
+		"min_samples_split": {"int", "Minimum number of samples required to split an internal node. Default is 2."},
+		"min_samples_leaf":  {"int", "Minimum number of samples required to be at a leaf node. Default is 1."},
+		"max_features":      {"int", "Number of features to consider when looking for the best split. Default is all features."},
+		"random_seed":       {"int", "Random seed for reproducibility. Default is current unix time in nanoseconds."},
+		"criterion":         {"string", "'gini' | 'entropy'. Default is gini."},
+	},
+	"ensemble_support": true,
+	"ensemble_methods": []string{"bagged"},
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                 "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                 "[class label]",
+			"max_depth":         "int",
+			"min_samples_split": "int",
+			"min_samples_leaf":  "int",
+			"max_features":      "int",
+			"random_seed":       "int",
+			"criterion":         "string",
+		},
+		"response": map[string]interface{}{
+			"classes":            "[class1, class2, ...]",
+			"tree_structure":     "{...} // leaves show their most frequent class",
+			"feature_importance": "[imp1, imp2, ...]",
+			"fit_metrics":        classificationMetricsDescription,
+		},
+	},
+}
+
+var ransacDocs = map[string]interface{}{
+	"description": "RANSAC regression. Fits the base estimator on random minimal subsets, keeps the largest consensus set of inliers and refits on it.",
+	"params": map[string][]string{
+		"base_estimator":        {"string", "Base estimator fitted on each subset, any of the supported base estimators."},
+		"base_estimator_params": {"object", "Params of the base estimator, {} if none."},
+		"min_samples":           {"int", "Rows per random subset. Default is n_features + 1."},
+		"residual_threshold":    {"float", "Maximum absolute residual of an inlier. Default is the median absolute deviation of Y."},
+		"max_trials":            {"int", "Number of random subsets to try. Default is 100."},
+		"random_seed":           {"int", "Random seed for reproducibility. Default is current unix time in nanoseconds."},
+	},
+	"supported_base_estimators": models,
+	"ensemble_support":          false,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                     "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                     "[target]",
+			"base_estimator":        "'linreg' | 'ols' | 'dectree' | 'ridge' | 'lasso' | 'elasticnet' | 'huber' | 'quantreg' | 'glm' | 'histgb' | 'randomforest' | 'extratrees'",
+			"base_estimator_params": "{...} // {} if no params",
+			"min_samples":           "int",
+			"residual_threshold":    "float",
+			"max_trials":            "int",
+			"random_seed":           "int",
+		},
+		"response": map[string]interface{}{
+			"inlier_mask":                "[bool, ...]",
+			"n_inliers":                  "int",
+			"n_trials":                   "int",
+			"base_estimator_fit_metrics": "metrics of the base estimator on the inliers",
+			"fit_metrics":                metricsDescription,
+		},
+	},
+}
+
+var conformalDocs = map[string]interface{}{
+	"description": "Conformal prediction. Wraps a base estimator to predict intervals containing the target with probability at least 1 - alpha, whatever the distribution of the data, by calibrating its residuals on rows it was not fitted on.",
+	"params": map[string][]string{
+		"base_estimator":              {"string", "Base estimator predicting the point estimate, any of the supported regression base estimators."},
+		"base_estimator_params":       {"object", "Params of the base estimator, {} if none."},
+		"method":                      {"string", "'split' calibrates on held-out rows, 'cv+' on the out-of-fold residuals of one estimator per fold, using every row for fitting and calibration. Default is split."},
+		"alpha":                       {"float", "Miscoverage in (0, 1), 0.1 gives 90% intervals. Default is 0.1."},
+		"calibration_fraction":        {"float", "Fraction of the rows held out for calibration, in (0, 1). Split only. Default is 0.25."},
+		"n_folds":                     {"int", "Number of folds, in [2, number of rows]. cv+ only. Default is 5."},
+		"difficulty_estimator":        {"string", "Optional. Estimator fitted to the absolute training residuals of the base estimator, scaling the intervals to be wider where it errs more. Any of the supported regression base estimators."},
+		"difficulty_estimator_params": {"object", "Params of the difficulty estimator, {} if none."},
+		"random_seed":                 {"int", "Random seed of the calibration split or folds. Default is current unix time in nanoseconds."},
+		"X_predict":                   {"[[float]]", "Rows to predict with intervals after fitting. Optional."},
+	},
+	"supported_base_estimators": models,
+	"ensemble_support":          false,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                           "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                           "[target]",
+			"base_estimator":              "'linreg' | 'ols' | 'dectree' | 'ridge' | 'lasso' | 'elasticnet' | 'huber' | 'quantreg' | 'glm' | 'histgb' | 'randomforest' | 'extratrees'",
+			"base_estimator_params":       "{...} // {} if no params",
+			"method":                      "'split' | 'cv+'",
+			"alpha":                       "float",
+			"calibration_fraction":        "float",
+			"n_folds":                     "int",
+			"difficulty_estimator":        "'linreg' | 'ols' | 'dectree' | ... // optional",
+			"difficulty_estimator_params": "{...}",
+			"random_seed":                 "int",
+			"X_predict":                   "[[feature1, feature2, ...], ...] // optional",
+		},
+		"response": map[string]interface{}{
+			"method":                     "'split' | 'cv+'",
+			"alpha":                      "float",
+			"n_calibration":              "int // rows whose residuals calibrate the intervals",
+			"base_estimator_fit_metrics": "metrics of the base estimator on its training rows",
+			"fit_metrics":                metricsDescription,
+			"predictions": map[string]string{
+				"prediction": "[pred1, pred2, ...] // point estimate for each X_predict row",
+				"lower":      "[float, ...] // lower bound of the conformal interval, null when unbounded",
+				"upper":      "[float, ...] // upper bound of the conformal interval, null when unbounded",
+			},
+		},
+	},
+}
+
+var theilSenDocs = map[string]interface{}{
+	"description": "Theil-Sen regression with an intercept. Takes the spatial median of least squares solutions over many small subsets of rows.",
+	"params": map[string][]string{
+		"n_subsamples":      {"int", "Rows per subset, >= n_features + 1. Default is n_features + 1."},
+		"max_subpopulation": {"int", "Subsets are sampled at random when there are more combinations than this. Default is 10000."},
+		"random_seed":       {"int", "Random seed for reproducibility. Default is current unix time in nanoseconds."},
+	},
+	"ensemble_support": false,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                 "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                 "[target]",
+			"n_subsamples":      "int",
+			"max_subpopulation": "int",
+			"random_seed":       "int",
+		},
+		"response": map[string]interface{}{
+			"coefficients": "[coef1, coef2, ...]",
+			"intercept":    "intercept",
+			"outlier_mask": "[bool, ...] // residual beyond 2.5 robust standard deviations",
+			"scale":        "float // robust residual standard deviation",
+			"n_subsets":    "int",
+			"fit_metrics":  metricsDescription,
+		},
+	},
+}
+
+var decTreeDocs = map[string]interface{}{
+	"description": "Decision Tree regression with configurable arguments.",
+	"params": map[string][]string{
+		"max_depth":         {"int", "Maximum depth of the tree. Default is 5."},
+		"min_samples_split": {"int", "Minimum number of samples required to split an internal node. Default is 2."},
+		"min_samples_leaf":  {"int", "Minimum number of samples required to be at a leaf node. Default is 1."},
+		"max_features":      {"int", "Number of features to consider when looking for the best split. Default is all features."},
+		"random_seed":       {"int", "Random seed for reproducibility. Default is current unix time in nanoseconds."},
+		"criterion":         {"string", "'squared_error' | 'absolute_error' | 'friedman_mse' | 'poisson'. Split criterion, also accepted in base_estimator_params when dectree is an ensemble base estimator. absolute_error leaves predict the median and suit heavy-tailed targets; poisson requires non-negative targets. Default is squared_error."},
+		"quantile":          {"float", "Optional. Fits the tree in quantile-leaf mode: leaves keep their training targets and predict this quantile of them instead of the mean."},
+		"quantiles":         {"[]float", "Optional. Quantiles to predict for every row of X, e.g. [0.05, 0.95] for a 90% prediction interval. Implies quantile-leaf mode (quantile defaults to 0.5)."},
+	},
+	"ensemble_support": true,
+	"ensemble_methods": ensembleMethods,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                 "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                 "[target]",
+			"max_depth":         "int",
+			"min_samples_split": "int",
+			"min_samples_leaf":  "int",
+			"max_features":      "int",
+			"random_seed":       "int",
+			"criterion":         "string",
+			"quantile":          "float",
+			"quantiles":         "[q1, q2, ...]",
+		},
+		"response": map[string]interface{}{
+			"tree_structure":       "{...}",
+			"feature_importance":   "[imp1, imp2, ...]",
+			"fit_metrics":          metricsDescription,
+			"pinball_loss":         "float // quantile-leaf mode only",
+			"quantile_predictions": "{\"q1\": [pred1, pred2, ...], ...} // only when quantiles are requested",
+		},
+	},
+}
+
+var baggedDocs = map[string]interface{}{
+	"description": "Bagging ensemble method. Combines the predictions of multiple base estimators trained on random subsets of the data. Classifier base estimators are combined by averaging their class probabilities (a random forest classifier for dectreeclassifier).",
+	"params": map[string][]string{
+		"n_estimators":       {"int", "The number of base estimators in the ensemble. Default is 10."},
+		"random_seed":        {"int", "Random seed for reproducibility. Default is current unix time in nanoseconds."},
+		"n_jobs":             {"int", "Number of goroutines fitting and evaluating the estimators, -1 uses every CPU. Results do not depend on it. Default is 1."},
+		"max_samples":        {"float", "Fraction of the rows drawn for each estimator. Default is 1."},
+		"bootstrap":          {"bool", "Draw the rows with replacement. False is pasting, where max_samples must be below 1 to leave out-of-bag rows. Default is true."},
+		"max_features":       {"float", "Fraction of the features drawn for each estimator (random subspaces, or random patches together with max_samples). Default is 1."},
+		"bootstrap_features": {"bool", "Draw the features with replacement. Default is false."},
+		"X_predict":          {"[[float]]", "Rows to predict after fitting, with the spread of the estimators and jackknife+-after-bootstrap prediction intervals. Regression base estimators only. Optional."},
+		"interval_alpha":     {"float", "Miscoverage of the intervals in (0, 1), 0.1 gives 90% intervals. Default is 0.1."},
+	},
+	"supported_base_estimators":  models,
+	"supported_base_classifiers": classifiers,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                     "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                     "[target]",
+			"base_estimator":        "'linreg' | 'ols' | 'dectree' | 'ridge' | 'lasso' | 'elasticnet' | 'huber' | 'quantreg' | 'glm' | 'histgb' | 'randomforest' | 'extratrees'",
+			"base_estimator_params": "{...} // {} if no params",
+			"n_estimators":          "int",
+			"random_seed":           "int",
+			"n_jobs":                "int",
+			"max_samples":           "float",
+			"bootstrap":             "bool",
+			"max_features":          "float",
+			"bootstrap_features":    "bool",
+			"X_predict":             "[[feature1, feature2, ...], ...] // optional",
+			"interval_alpha":        "float",
+		},
+		"response": map[string]interface{}{
+			"base_estimator_fit_metrics": "[{...}, {...}, ...]",
+			"features":                   "[[f1, f2, ...], ...] // feature subset of each estimator, null entries used every feature",
+			"fit_metrics":                metricsDescription,
+			"classes":                    "[class1, class2, ...] // classifier base estimators only, with classification metrics",
+			"oob_score":                  "float // R-squared of the out-of-bag predictions, accuracy for classifier base estimators",
+			"oob_predictions":            "[pred1, pred2, ...] // each row predicted by the estimators whose bootstrap sample left it out, null if every sample drew it",
+			"oob_metrics":                "metrics of the out-of-bag predictions, classification metrics for classifier base estimators",
+			"predictions":                predictionsDescription,
+		},
+	},
+}
+
+var boostedDocs = map[string]interface{}{
+	"description": "Gradient boosting ensemble method. Starts from the constant minimizing the loss, then fits each base estimator to the pseudo-residuals (negative loss gradient) of the current predictions and adds it scaled by a line search and shrunk by the learning rate.",
+	"params": map[string][]string{
+		"n_estimators":        {"int", "The number of base estimators in the ensemble. Default is 10."},
+		"learning_rate":       {"float", "Learning rate shrinks the contribution of each correction stage (not the initial prediction). Default is 0.1."},
+		"loss":                {"string", "'squared_error' | 'absolute_error' | 'huber' | 'quantile'. Default is squared_error."},
+		"alpha":               {"float", "Quantile predicted by the quantile loss, or quantile of the absolute residuals beyond which the huber loss is linear. Default is 0.9."},
+		"subsample":           {"float", "Fraction of rows, drawn without replacement, each stage is fitted on (stochastic gradient boosting). Values below 1 enable out-of-bag improvement tracking. Default is 1."},
+		"colsample_bytree":    {"float", "Fraction of features each stage is fitted on. Default is 1."},
+		"random_seed":         {"int", "Random seed of the row and feature subsampling and of the validation split. Default is 0."},
+		"X_validation":        {"[[float]]", "Validation features for the loss curve and early stopping. Optional."},
+		"Y_validation":        {"[float]", "Validation targets, required with X_validation."},
+		"validation_fraction": {"float", "Fraction of rows held out as validation set when X_validation is not given, 0 trains on every row. Default is 0."},
+		"n_iter_no_change":    {"int", "Stop once the validation loss has not improved for this many stages, 0 disables early stopping. Default is 0."},
+		"tol":                 {"float", "Minimum improvement of the validation loss. Default is 1e-4."},
+	},
+	"supported_base_estimators": models,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                     "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                     "[target]",
+			"base_estimator":        "'linreg' | 'ols' | 'dectree' | 'ridge' | 'lasso' | 'elasticnet' | 'huber' | 'quantreg' | 'glm' | 'histgb' | 'randomforest' | 'extratrees'",
+			"base_estimator_params": "{...} // {} if no params",
+			"n_estimators":          "int",
+			"learning_rate":         "float",
+			"loss":                  "string",
+			"alpha":                 "float",
+			"subsample":             "float",
+			"colsample_bytree":      "float",
+			"random_seed":           "int",
+			"X_validation":          "[[feature1, feature2, ...], ...]",
+			"Y_validation":          "[target]",
+			"validation_fraction":   "float",
+			"n_iter_no_change":      "int",
+			"tol":                   "float",
+		},
+		"response": map[string]interface{}{
+			"base_estimator_fit_response": "[{...}, {...}, ...] // fit metrics of each stage on its pseudo-residuals, null where not finite",
+			"n_estimators_fitted":         "int // fewer than n_estimators when early stopping triggered",
+			"train_loss":                  "[float, ...] // loss on the training rows after each stage",
+			"validation_loss":             "[float, ...] // loss on the validation set after each stage, empty without one",
+			"loss":                        "string",
+			"initial_prediction":          "float",
+			"step_sizes":                  "[rho1, rho2, ...] // line search multiplier of each stage, before shrinkage",
+			"leaf_values":                 "[[gamma1, gamma2, ...], ...] // per-leaf line search of each tree stage, null for other stages",
+			"features":                    "[[f1, f2, ...], ...] // feature subset of each stage, null entries used every feature",
+			"oob_improvement":             "[float, ...] // loss decrease of each stage on its out-of-bag rows, subsample < 1 only",
+			"oob_best_n_estimators":       "int // stage count where the cumulative out-of-bag improvement peaks, subsample < 1 only",
+			"fit_metrics":                 metricsDescription,
+		},
+	},
+}
+
+var adaBoostDocs = map[string]interface{}{
+	"description": "AdaBoost.R2 ensemble method. Each base estimator is fitted to the rows weighted by the errors of the previous ones, by sample reweighting for estimators that accept weights ('ols', 'linreg') and by weighted resampling otherwise, and the ensemble predicts the weighted median of their predictions.",
+	"params": map[string][]string{
+		"n_estimators":  {"int", "The maximum number of base estimators in the ensemble, fewer are fitted once one is no better than chance. Default is 50."},
+		"learning_rate": {"float", "Shrinks the weight of each estimator and the reweighting of the rows. Default is 1."},
+		"loss":          {"string", "'linear' | 'square' | 'exponential', applied to each absolute error divided by the largest one. Default is linear."},
+		"random_seed":   {"int", "Random seed of the weighted resampling. Default is 0."},
+	},
+	"supported_base_estimators": models,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                     "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                     "[target]",
+			"base_estimator":        "'linreg' | 'ols' | 'dectree' | 'ridge' | 'lasso' | 'elasticnet' | 'huber' | 'quantreg' | 'glm' | 'histgb' | 'randomforest' | 'extratrees'",
+			"base_estimator_params": "{...} // {} if no params",
+			"n_estimators":          "int",
+			"learning_rate":         "float",
+			"loss":                  "string",
+			"random_seed":           "int",
+		},
+		"response": map[string]interface{}{
+			"base_estimator_fit_response": "[{...}, {...}, ...]",
+			"loss":                        "string",
+			"sample_weighting":            "'reweighting' | 'resampling'",
+			"estimator_weights":           "[float, ...] // ln(1 / beta) of each estimator, its weight in the median",
+			"estimator_errors":            "[float, ...] // weighted average loss of each estimator",
+			"fit_metrics":                 metricsDescription,
+		},
+	},
+}
+
+var baseEstimatorsDoc = "[{\"base_estimator\": 'linreg' | 'ols' | 'dectree' | ..., \"base_estimator_params\": {...}, \"ensemble\": '' | 'bagged' | 'boosted' | 'adaboost', \"n_estimators\": int, \"random_seed\": int}, ...]"
+
+var stackedDocs = map[string]interface{}{
+	"description": "Stacking ensemble method over different base estimators. Out-of-fold predictions of every base estimator, from k-fold cross-validation, are the features of a meta-estimator; the base estimators are then refitted on all rows.",
+	"params": map[string][]string{
+		"base_estimators":       {"[object]", "The base estimators, each with its own params and optionally wrapped in a bagged, boosted or adaboost ensemble of n_estimators copies (default 10)."},
+		"meta_estimator":        {"string", "Estimator fitted on the out-of-fold predictions. Default is 'ridge'."},
+		"meta_estimator_params": {"object", "Params of the meta-estimator. Default is {}."},
+		"n_folds":               {"int", "Number of cross-validation folds. Default is 5."},
+		"random_seed":           {"int", "Random seed of the fold assignment. Default is 0."},
+	},
+	"supported_base_estimators": models,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":                     "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":                     "[target]",
+			"base_estimators":       baseEstimatorsDoc,
+			"meta_estimator":        "'linreg' | 'ols' | 'dectree' | 'ridge' | 'lasso' | 'elasticnet' | 'huber' | 'quantreg' | 'glm' | 'histgb' | 'randomforest' | 'extratrees'",
+			"meta_estimator_params": "{...} // {} if no params",
+			"n_folds":               "int",
+			"random_seed":           "int",
+		},
+		"response": map[string]interface{}{
+			"base_estimator_fit_response": "[{...}, {...}, ...] // fits of each base estimator on all rows",
+			"base_estimator_oof_metrics":  "[{...}, {...}, ...] // out-of-fold metrics of each base estimator",
+			"oof_metrics":                 "metrics of the meta-estimator on the out-of-fold predictions",
+			"fit_metrics":                 metricsDescription,
+		},
+	},
+}
+
+var votingDocs = map[string]interface{}{
+	"description": "Voting ensemble method. Fits different base estimators on all rows and predicts the (weighted) average of their predictions.",
+	"params": map[string][]string{
+		"base_estimators": {"[object]", "The base estimators, each with its own params and optionally wrapped in a bagged, boosted or adaboost ensemble of n_estimators copies (default 10)."},
+		"weights":         {"[float]", "Non-negative weight of each base estimator. Default weights them equally."},
+	},
+	"supported_base_estimators": models,
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":               "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":               "[target]",
+			"base_estimators": baseEstimatorsDoc,
+			"weights":         "[w1, w2, ...]",
+		},
+		"response": map[string]interface{}{
+			"base_estimator_fit_response": "[{...}, {...}, ...]",
+			"fit_metrics":                 metricsDescription,
+		},
+	},
+}
+
+var diagnosticsDocs = map[string]interface{}{
+	"description": "Regression diagnostics of a linear fit: multicollinearity (VIF), residual autocorrelation (Durbin-Watson), heteroscedasticity (Breusch-Pagan), residual normality (Jarque-Bera) and influential points (leverage, Cook's distance). Non-finite values are returned as null.",
+	"params": map[string][]string{
+		"model":         {"string", "Linear model to fit before running diagnostics, 'ols' or 'linreg'. Default is 'ols'."},
+		"residuals":     {"[float]", "Residuals of an existing fit. When provided, no model is fitted and Y is ignored."},
+		"fit_intercept": {"bool", "Whether the fit that produced the residuals had an intercept. Default is true."},
+	},
+	"request_format": map[string]interface{}{
+		"type": "POST",
+		"body": map[string]string{
+			"X":             "[[feature1, feature2, ...], [feature1, feature2, ...], ...]",
+			"Y":             "[target]",
+			"model":         "'ols' | 'linreg'",
+			"residuals":     "[resid1, resid2, ...] // optional",
+			"fit_intercept": "bool // optional",
+		},
+		"response": map[string]interface{}{
+			"diagnostics": map[string]string{
+				"vif":                "[vif1, vif2, ...]",
+				"durbin_watson":      "float",
+				"breusch_pagan":      "{statistic, p_value, df}",
+				"jarque_bera":        "{statistic, p_value, df, skew, kurtosis}",
+				"leverage":           "[h1, h2, ...]",
+				"cooks_distance":     "[d1, d2, ...]",
+				"influential_points": "[row1, row2, ...] // Cook's distance > 4/n",
+			},
+			"summary": "string // text report",
+		},
+	},
+}
+
+var endpointUsage = map[string]interface{}{
+	"estimators": map[string]interface{}{
+		"/linreg":       linRegDocs,
+		"/ols":          olsDocs,
+		"/dectree":      decTreeDocs,
+		"/gls":          glsDocs,
+		"/ridge":        ridgeDocs,
+		"/lasso":        lassoDocs,
+		"/elasticnet":   elasticNetDocs,
+		"/huber":        huberDocs,
+		"/quantreg":     quantRegDocs,
+		"/glm":          glmDocs,
+		"/histgb":       histGBDocs,
+		"/randomforest": randomForestDocs,
+		"/extratrees":   extraTreesDocs,
+		"/ransac":       ransacDocs,
+		"/theilsen":     theilSenDocs,
+		"/ridgecv":      ridgeCVDocs,
+		"/lassocv":      lassoCVDocs,
+		"/elasticnetcv": elasticNetCVDocs,
+		"/conformal":    conformalDocs,
+	},
+	"classifiers": map[string]interface{}{
+		"/logistic":          logRegDocs,
+		"/dectreeclassifier": decTreeClassifierDocs,
+	},
+	"ensembles": map[string]interface{}{
+		"/bagged":   baggedDocs,
+		"/boosted":  boostedDocs,
+		"/adaboost": adaBoostDocs,
+		"/stacked":  stackedDocs,
+		"/voting":   votingDocs,
+	},
+	"diagnostics": diagnosticsDocs,
+}
+
+// GET handlers for each endpoint to return the documentation as JSON
+
+func ModelsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET is allowed on the /models endpoint", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(endpointUsage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return
+}
+
+func EstimatorsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(endpointUsage["estimators"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return
+}
+
+func ClassifiersHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(endpointUsage["classifiers"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return
+}
+
+func LinRegGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(linRegDocs)
+	return
+}
+
+func OLSGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(olsDocs)
+	return
+}
+
+func GLSGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(glsDocs)
+	return
+}
+
+func RidgeGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(ridgeDocs)
+	return
+}
+
+func LassoGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(lassoDocs)
+	return
+}
+
+func ElasticNetGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(elasticNetDocs)
+	return
+}
+
+func RidgeCVGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(ridgeCVDocs)
+	return
+}
+
+func LassoCVGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(lassoCVDocs)
+	return
+}
+
+func ElasticNetCVGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(elasticNetCVDocs)
+	return
+}
+
+func HuberGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(huberDocs)
+	return
+}
+
+func QuantRegGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(quantRegDocs)
+	return
+}
+
+func RandomForestGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(randomForestDocs)
+	return
+}
+
+func ExtraTreesGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(extraTreesDocs)
+	return
+}
+
+func HistGBGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(histGBDocs)
+	return
+}
+
+func GLMGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(glmDocs)
+	return
+}
+
+func LogRegGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(logRegDocs)
+	return
+}
+
+func DecTreeClassifierGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(decTreeClassifierDocs)
+	return
+}
+
+func ConformalGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(conformalDocs)
+	return
+}
+
+func RANSACGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(ransacDocs)
+	return
+}
+
+func TheilSenGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(theilSenDocs)
+	return
+}
+
+func DecTreeGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(decTreeDocs)
+	return
+}
+
+func EnsemblesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(endpointUsage["ensembles"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return
+}
+
+func BaggedGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(baggedDocs)
+	return
+}
+
+func BoostedGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(boostedDocs)
+	return
+}
+
+func AdaBoostGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(adaBoostDocs)
+	return
+}
+
+func StackedGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(stackedDocs)
+	return
+}
+
+func VotingGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(votingDocs)
+	return
+}
+
+func DiagnosticsGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(diagnosticsDocs)
+	return
+}
+
+// POST handlers for each endpoint to handle model training and prediction
+
+func LinRegPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams LeastSquaresPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	var model *LinReg.LinReg
+	if modelParams.Weights != nil {
+		model = LinReg.NewWeightedLinReg(X, Y, modelParams.Weights).(*LinReg.LinReg)
+	} else {
+		model = LinReg.NewLinReg(X, Y).(*LinReg.LinReg)
+	}
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients": model.Coefs,
+		"fit_metrics":  model.GetMetrics(),
+		"inference":    model.Inference,
+		"summary":      model.Summary(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func OLSPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams LeastSquaresPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	var model *OLS.OLS
+	if modelParams.Weights != nil {
+		model = OLS.NewWLS(X, Y, modelParams.Weights).(*OLS.OLS)
+	} else {
+		model = OLS.NewOLS(X, Y).(*OLS.OLS)
+	}
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients": model.Coefs,
+		"intercept":    model.Intercept,
+		"fit_metrics":  model.GetMetrics(),
+		"inference":    model.Inference,
+		"summary":      model.Summary(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func GLSPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams GLSPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	var covariance OLS.Covariance
+	switch {
+	case modelParams.Sigma != nil:
+		if len(modelParams.Sigma) != len(Y) {
+			return errors.New("sigma must be an n x n matrix where n is the number of rows")
+		}
+		covariance = OLS.NewFullCovariance(modelParams.Sigma)
+	case modelParams.Rho != nil:
+		if *modelParams.Rho <= -1 || *modelParams.Rho >= 1 {
+			return errors.New("rho must be in (-1, 1)")
+		}
+		covariance = OLS.AR1{Rho: *modelParams.Rho}
+	}
+
+	model := OLS.NewGLS(X, Y, covariance).(*OLS.GLS)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients": model.Coefs,
+		"intercept":    model.Intercept,
+		"fit_metrics":  model.GetMetrics(),
+		"inference":    model.Inference,
+		"summary":      model.Summary(),
+	}
+	if ar, ok := model.FittedCovariance.(OLS.AR1); ok {
+		resp["rho"] = ar.Rho
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func RidgePostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams RidgePostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	alpha := 1.0
+	if modelParams.Alpha != nil {
+		alpha = *modelParams.Alpha
+	}
+	fitIntercept := true
+	if modelParams.FitIntercept != nil {
+		fitIntercept = *modelParams.FitIntercept
+	}
+
+	model := Ridge.NewRidge(X, Y, alpha, fitIntercept).(*Ridge.Ridge)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients": model.Coefs,
+		"intercept":    model.Intercept,
+		"alpha":        model.Alpha,
+		"fit_metrics":  model.GetMetrics(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func LassoPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	return elasticNetPost(w, r, true)
+}
+
+func ElasticNetPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	return elasticNetPost(w, r, false)
+}
+
+// elasticNetPost serves both sparse linear models; lasso pins l1_ratio to 1.
+func elasticNetPost(w http.ResponseWriter, r *http.Request, isLasso bool) (err error) {
+	var modelParams ElasticNetPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	alpha := 1.0
+	if modelParams.Alpha != nil {
+		alpha = *modelParams.Alpha
+	}
+	l1Ratio := 0.5
+	if isLasso {
+		l1Ratio = 1.0
+	} else if modelParams.L1Ratio != nil {
+		l1Ratio = *modelParams.L1Ratio
+	}
+	maxIter := 1000
+	if modelParams.MaxIter != nil {
+		maxIter = *modelParams.MaxIter
+	}
+	tol := 1e-4
+	if modelParams.Tol != nil {
+		tol = *modelParams.Tol
+	}
+	fitIntercept := true
+	if modelParams.FitIntercept != nil {
+		fitIntercept = *modelParams.FitIntercept
+	}
+
+	model := ElasticNet.NewElasticNet(X, Y, alpha, l1Ratio, maxIter, tol, fitIntercept).(*ElasticNet.ElasticNet)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients":      model.Coefs,
+		"intercept":         model.Intercept,
+		"zero_coefficients": model.ZeroCoefs,
+		"n_iter":            model.NIter,
+		"converged":         model.Converged,
+		"fit_metrics":       model.GetMetrics(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
 	return
 }
 
-func OLSGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+func RidgeCVPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams CVPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	nAlphas := 50
+	if modelParams.NAlphas != nil {
+		nAlphas = *modelParams.NAlphas
+	}
+	nFolds := 5
+	if modelParams.NFolds != nil {
+		nFolds = *modelParams.NFolds
+	}
+	fitIntercept := true
+	if modelParams.FitIntercept != nil {
+		fitIntercept = *modelParams.FitIntercept
+	}
+
+	model := Ridge.NewRidgeCV(X, Y, nAlphas, nFolds, fitIntercept, modelParams.RandomSeed).(*Ridge.RidgeCV)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients": model.Coefs,
+		"intercept":    model.Intercept,
+		"best_alpha":   model.BestAlpha,
+		"path":         model.Path,
+		"fit_metrics":  model.GetMetrics(),
+	}
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(olsDocs)
+	err = json.NewEncoder(w).Encode(resp)
 	return
 }
 
-func DecTreeGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+func LassoCVPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	return elasticNetCVPost(w, r, true)
+}
+
+func ElasticNetCVPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	return elasticNetCVPost(w, r, false)
+}
+
+// elasticNetCVPost serves both cross-validated sparse linear models; lasso pins l1_ratio to 1.
+func elasticNetCVPost(w http.ResponseWriter, r *http.Request, isLasso bool) (err error) {
+	var modelParams CVPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	l1Ratio := 0.5
+	if isLasso {
+		l1Ratio = 1.0
+	} else if modelParams.L1Ratio != nil {
+		l1Ratio = *modelParams.L1Ratio
+	}
+	nAlphas := 100
+	if modelParams.NAlphas != nil {
+		nAlphas = *modelParams.NAlphas
+	}
+	nFolds := 5
+	if modelParams.NFolds != nil {
+		nFolds = *modelParams.NFolds
+	}
+	maxIter := 1000
+	if modelParams.MaxIter != nil {
+		maxIter = *modelParams.MaxIter
+	}
+	tol := 1e-4
+	if modelParams.Tol != nil {
+		tol = *modelParams.Tol
+	}
+	fitIntercept := true
+	if modelParams.FitIntercept != nil {
+		fitIntercept = *modelParams.FitIntercept
+	}
+
+	model := ElasticNet.NewElasticNetCV(X, Y, l1Ratio, nAlphas, nFolds, maxIter, tol, fitIntercept, modelParams.RandomSeed).(*ElasticNet.ElasticNetCV)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients":      model.Coefs,
+		"intercept":         model.Intercept,
+		"zero_coefficients": model.ZeroCoefs,
+		"best_alpha":        model.BestAlpha,
+		"path":              model.Path,
+		"fit_metrics":       model.GetMetrics(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func HuberPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams HuberPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	epsilon := 1.35
+	if modelParams.Epsilon != nil {
+		epsilon = *modelParams.Epsilon
+	}
+	maxIter := 100
+	if modelParams.MaxIter != nil {
+		maxIter = *modelParams.MaxIter
+	}
+	tol := 1e-5
+	if modelParams.Tol != nil {
+		tol = *modelParams.Tol
+	}
+
+	model := Robust.NewHuber(X, Y, epsilon, maxIter, tol).(*Robust.Huber)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients": model.Coefs,
+		"intercept":    model.Intercept,
+		"weights":      model.Weights,
+		"outlier_mask": model.OutlierMask,
+		"scale":        model.Scale,
+		"n_iter":       model.NIter,
+		"converged":    model.Converged,
+		"fit_metrics":  model.GetMetrics(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func QuantRegPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams QuantRegPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	quantile := 0.5
+	if modelParams.Quantile != nil {
+		quantile = *modelParams.Quantile
+	}
+	maxIter := 500
+	if modelParams.MaxIter != nil {
+		maxIter = *modelParams.MaxIter
+	}
+	tol := 1e-6
+	if modelParams.Tol != nil {
+		tol = *modelParams.Tol
+	}
+
+	model := QuantReg.NewQuantReg(X, Y, quantile, maxIter, tol).(*QuantReg.QuantReg)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"coefficients": model.Coefs,
+		"intercept":    model.Intercept,
+		"pinball_loss": model.PinballLoss,
+		"coverage":     model.Coverage,
+		"n_iter":       model.NIter,
+		"converged":    model.Converged,
+		"fit_metrics":  model.GetMetrics(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func GLMPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams GLMPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	power := 1.5
+	if modelParams.Power != nil {
+		power = *modelParams.Power
+	}
+	family, link, err := glmFamily(modelParams.Family, modelParams.Link, power)
+	if err != nil {
+		http.Error(w, "Unsupported family or link", http.StatusBadRequest)
+		return
+	}
+	maxIter := 100
+	if modelParams.MaxIter != nil {
+		maxIter = *modelParams.MaxIter
+	}
+	tol := 1e-8
+	if modelParams.Tol != nil {
+		tol = *modelParams.Tol
+	}
+
+	model := GLM.NewGLM(X, Y, family, link, maxIter, tol).(*GLM.GLM)
+	model.Fit()
+
+	// encoding/json rejects NaN, report a likelihood without closed form as null
+	var logLikelihood, aic interface{}
+	if !math.IsNaN(model.AIC) {
+		logLikelihood, aic = model.LogLikelihood, model.AIC
+	}
+
+	resp := map[string]interface{}{
+		"coefficients":   model.Coefs,
+		"intercept":      model.Intercept,
+		"family":         model.Family.Name(),
+		"link":           model.Link.Name(),
+		"deviance":       model.Deviance,
+		"null_deviance":  model.NullDeviance,
+		"d2":             model.D2,
+		"scale":          model.Scale,
+		"log_likelihood": logLikelihood,
+		"aic":            aic,
+		"n_iter":         model.NIter,
+		"converged":      model.Converged,
+		"fit_metrics":    nullableMetrics(model.GetMetrics()),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func RandomForestPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	return forestPostHandler(w, r, Forest.NewRandomForest)
+}
+
+func ExtraTreesPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	return forestPostHandler(w, r, Forest.NewExtraTrees)
+}
+
+// forestPostHandler fits the RandomForest or ExtraTrees built by newForest.
+func forestPostHandler(w http.ResponseWriter, r *http.Request, newForest func(X [][]float64, Y []float64, nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf, maxFeatures int, randSeed *int64) Ensemble.Estimator) (err error) {
+	var modelParams ForestPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+	if len(X) == 0 || len(X) != len(Y) {
+		http.Error(w, "X and Y must be non-empty with the same number of rows", http.StatusBadRequest)
+		return
+	}
+
+	nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf := 100, 10, 2, 1
+	if modelParams.NEstimators != nil {
+		nEstimators = *modelParams.NEstimators
+	}
+	if modelParams.MaxDepth != nil {
+		maxDepth = *modelParams.MaxDepth
+	}
+	if modelParams.MinSamplesSplit != nil {
+		minSamplesSplit = *modelParams.MinSamplesSplit
+	}
+	if modelParams.MinSamplesLeaf != nil {
+		minSamplesLeaf = *modelParams.MinSamplesLeaf
+	}
+	if nEstimators <= 0 {
+		http.Error(w, "n_estimators must be positive", http.StatusBadRequest)
+		return
+	}
+	if modelParams.MaxFeatures < 0 || modelParams.MaxFeatures > len(X[0]) {
+		http.Error(w, "max_features must be 0 for the default or in [1, number of features]", http.StatusBadRequest)
+		return
+	}
+
+	criterion, err := treeCriterion(modelParams.Criterion)
+	if err != nil {
+		http.Error(w, "Unsupported criterion", http.StatusBadRequest)
+		return
+	}
+	if _, ok := criterion.(DecTree.Poisson); ok && slices.ContainsFunc(Y, func(target float64) bool { return target < 0 }) {
+		http.Error(w, "Poisson criterion requires non-negative targets", http.StatusBadRequest)
+		return
+	}
+
+	alpha, err := modelParams.validate(len(X[0]))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	model := newForest(X, Y, nEstimators, maxDepth, minSamplesSplit, minSamplesLeaf, modelParams.MaxFeatures, modelParams.RandomSeed)
+	forest := forestOf(model)
+	forest.Criterion = criterion
+	forest.NJobs = modelParams.NJobs
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"max_features":        forest.MaxFeatures,
+		"feature_importances": forest.FeatureImportances,
+		"oob_score":           forest.OOBScore,
+		"oob_predictions":     nullableFloats(forest.OOBPredictions()),
+		"oob_metrics":         nullableMetrics(forest.OOBMetrics),
+		"fit_metrics":         nullableMetrics(model.GetMetrics()),
+	}
+	if modelParams.XPredict != nil {
+		resp["predictions"] = uncertaintyPredictions(forest.Bagged, modelParams.XPredict, alpha)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func HistGBPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams HistGBPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	learningRate := 0.1
+	if modelParams.LearningRate != nil {
+		learningRate = *modelParams.LearningRate
+	}
+	maxIter := 100
+	if modelParams.MaxIter != nil {
+		maxIter = *modelParams.MaxIter
+	}
+	maxLeafNodes := 31
+	if modelParams.MaxLeafNodes != nil {
+		maxLeafNodes = *modelParams.MaxLeafNodes
+	}
+	minSamplesLeaf := 20
+	if modelParams.MinSamplesLeaf != nil {
+		minSamplesLeaf = *modelParams.MinSamplesLeaf
+	}
+	l2Regularization := 0.0
+	if modelParams.L2Regularization != nil {
+		l2Regularization = *modelParams.L2Regularization
+	}
+	validationFraction := 0.1
+	if modelParams.ValidationFraction != nil {
+		validationFraction = *modelParams.ValidationFraction
+	}
+	nIterNoChange := 10
+	if modelParams.NIterNoChange != nil {
+		nIterNoChange = *modelParams.NIterNoChange
+	}
+	tol := 1e-7
+	if modelParams.Tol != nil {
+		tol = *modelParams.Tol
+	}
+
+	model := HistGB.NewHistGradientBoosting(X, Y, learningRate, maxIter, maxLeafNodes, minSamplesLeaf, l2Regularization, validationFraction, nIterNoChange, tol, modelParams.RandomSeed).(*HistGB.HistGradientBoosting)
+	if modelParams.MaxBins != nil {
+		if *modelParams.MaxBins < 2 || *modelParams.MaxBins > 255 {
+			http.Error(w, "max_bins must be in [2, 255]", http.StatusBadRequest)
+			return
+		}
+		model.MaxBins = *modelParams.MaxBins
+	}
+	model.Fit()
+
+	validationLoss := model.ValidationLoss
+	if validationLoss == nil {
+		validationLoss = []float64{}
+	}
+	resp := map[string]interface{}{
+		"baseline":        model.Baseline,
+		"n_iter":          model.NIter,
+		"train_loss":      model.TrainLoss,
+		"validation_loss": validationLoss,
+		"fit_metrics":     model.GetMetrics(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func LogRegPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams LogRegPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	alpha := 1.0
+	if modelParams.Alpha != nil {
+		alpha = *modelParams.Alpha
+	}
+	fitIntercept := true
+	if modelParams.FitIntercept != nil {
+		fitIntercept = *modelParams.FitIntercept
+	}
+	maxIter := 500
+	if modelParams.MaxIter != nil {
+		maxIter = *modelParams.MaxIter
+	}
+	tol := 1e-6
+	if modelParams.Tol != nil {
+		tol = *modelParams.Tol
+	}
+
+	model := LogReg.NewLogReg(X, Y, alpha, fitIntercept, maxIter, tol).(*LogReg.LogReg)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"classes":      model.Classes,
+		"coefficients": model.Coefs,
+		"intercepts":   model.Intercepts,
+		"multinomial":  model.Multinomial,
+		"n_iter":       model.NIter,
+		"converged":    model.Converged,
+		"fit_metrics":  model.GetClassificationMetrics(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func DecTreeClassifierPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams DecTreeClassifierPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	criterion := modelParams.Criterion
+	if criterion == "" {
+		criterion = "gini"
+	}
+	if criterion != "gini" && criterion != "entropy" {
+		http.Error(w, "Unsupported criterion", http.StatusBadRequest)
+		return
+	}
+	randomSeed := modelParams.RandomSeed
+	maxFeatures := modelParams.MaxFeatures
+
+	model := DecTree.NewDecTreeClassifier(X, Y, modelParams.MaxDepth, modelParams.MinSamplesSplit, modelParams.MinSamplesLeaf, criterion, &randomSeed, &maxFeatures).(*DecTree.DecTreeClassifier)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"classes":            model.Classes,
+		"tree_structure":     model.GetTreeString(),
+		"feature_importance": model.GetFeatureImportance(),
+		"fit_metrics":        model.GetClassificationMetrics(),
+	}
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(decTreeDocs)
+	err = json.NewEncoder(w).Encode(resp)
 	return
 }
 
-func EnsemblesHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(endpointUsage["ensembles"])
+func RANSACPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams RANSACPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
 	}
-	return
-}
+	X := modelParams.X
+	Y := modelParams.Y
 
-func BaggedGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(baggedDocs)
-	return
-}
+	baseEstimatorFactory, err := ensembleFactoryConstructor(modelParams.BaseEstimator, modelParams.BaseEstimatorParams)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	maxTrials := 100
+	if modelParams.MaxTrials != nil {
+		maxTrials = *modelParams.MaxTrials
+	}
 
-func BoostedGetHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	model := Robust.NewRANSAC(baseEstimatorFactory, X, Y, modelParams.MinSamples, modelParams.ResidualThreshold, maxTrials, modelParams.RandomSeed).(*Robust.RANSAC)
+	model.Fit()
+
+	resp := map[string]interface{}{
+		"inlier_mask":                model.InlierMask,
+		"n_inliers":                  model.NInliers,
+		"n_trials":                   model.NTrials,
+		"base_estimator_fit_metrics": model.Estimator.GetMetrics(),
+		"fit_metrics":                model.GetMetrics(),
+	}
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(boostedDocs)
+	err = json.NewEncoder(w).Encode(resp)
 	return
 }
 
-// POST handlers for each endpoint to handle model training and prediction
-
-func LinRegPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
-	var modelParams AbstractPostBody
+func ConformalPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams ConformalPostBody
 	err = json.NewDecoder(r.Body).Decode(&modelParams)
 	if err != nil {
 		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
@@ -273,21 +2471,97 @@ func LinRegPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
 	}
 	X := modelParams.X
 	Y := modelParams.Y
+	if len(X) < 2 || len(X) != len(Y) {
+		http.Error(w, "X and Y must have the same number of rows, at least 2", http.StatusBadRequest)
+		return
+	}
+
+	alpha, calibrationFraction, nFolds := 0.1, 0.25, 5
+	if modelParams.Alpha != nil {
+		alpha = *modelParams.Alpha
+	}
+	if modelParams.CalibrationFraction != nil {
+		calibrationFraction = *modelParams.CalibrationFraction
+	}
+	if modelParams.NFolds != nil {
+		nFolds = *modelParams.NFolds
+	}
+	if alpha <= 0 || alpha >= 1 {
+		http.Error(w, "alpha must be in (0, 1)", http.StatusBadRequest)
+		return
+	}
+	for _, row := range modelParams.XPredict {
+		if len(row) != len(X[0]) {
+			http.Error(w, "X_predict rows must have as many features as X", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if slices.Contains(classifiers, modelParams.BaseEstimator) || slices.Contains(classifiers, modelParams.DifficultyEstimator) {
+		http.Error(w, "The base and difficulty estimators must be regression estimators", http.StatusBadRequest)
+		return
+	}
+	baseEstimatorFactory, err := ensembleFactoryConstructor(modelParams.BaseEstimator, modelParams.BaseEstimatorParams)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	model := LinReg.NewLinReg(X, Y).(*LinReg.LinReg)
+	var model *conformal.Conformal
+	switch modelParams.Method {
+	case "", conformal.Split:
+		if calibrationFraction <= 0 || calibrationFraction >= 1 {
+			http.Error(w, "calibration_fraction must be in (0, 1)", http.StatusBadRequest)
+			return
+		}
+		model = conformal.NewSplitConformal(baseEstimatorFactory, X, Y, alpha, calibrationFraction, modelParams.RandomSeed).(*conformal.Conformal)
+	case conformal.CVPlus:
+		if nFolds < 2 || nFolds > len(Y) {
+			http.Error(w, "n_folds must be in [2, number of rows]", http.StatusBadRequest)
+			return
+		}
+		model = conformal.NewCVPlusConformal(baseEstimatorFactory, X, Y, alpha, nFolds, modelParams.RandomSeed).(*conformal.Conformal)
+	default:
+		http.Error(w, "Unsupported method", http.StatusBadRequest)
+		return
+	}
+	if modelParams.DifficultyEstimator != "" {
+		model.DifficultyFactory, err = ensembleFactoryConstructor(modelParams.DifficultyEstimator, modelParams.DifficultyEstimatorParams)
+		if err != nil {
+			http.Error(w, "difficulty_estimator: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 	model.Fit()
 
 	resp := map[string]interface{}{
-		"coefficients": model.Coefs,
-		"fit_metrics":  model.GetMetrics(),
+		"method":                     model.Method,
+		"alpha":                      model.Alpha,
+		"n_calibration":              len(model.Scores),
+		"base_estimator_fit_metrics": nullableMetrics(model.Estimator.GetMetrics()),
+		"fit_metrics":                nullableMetrics(model.GetMetrics()),
+	}
+	if modelParams.XPredict != nil {
+		preds := make([]float64, len(modelParams.XPredict))
+		lowers := make([]float64, len(modelParams.XPredict))
+		uppers := make([]float64, len(modelParams.XPredict))
+		for i, row := range modelParams.XPredict {
+			preds[i] = model.Predict(row)
+			lowers[i], uppers[i] = model.PredictInterval(row)
+		}
+		resp["predictions"] = map[string]interface{}{
+			"prediction": preds,
+			"lower":      nullableFloats(lowers),
+			"upper":      nullableFloats(uppers),
+		}
 	}
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(resp)
 	return
 }
 
-func OLSPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
-	var modelParams AbstractPostBody
+func TheilSenPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams TheilSenPostBody
 	err = json.NewDecoder(r.Body).Decode(&modelParams)
 	if err != nil {
 		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
@@ -296,12 +2570,20 @@ func OLSPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
 	X := modelParams.X
 	Y := modelParams.Y
 
-	model := OLS.NewOLS(X, Y).(*OLS.OLS)
+	maxSubpopulation := 10000
+	if modelParams.MaxSubpopulation != nil {
+		maxSubpopulation = *modelParams.MaxSubpopulation
+	}
+
+	model := Robust.NewTheilSen(X, Y, modelParams.NSubsamples, maxSubpopulation, 300, 1e-3, modelParams.RandomSeed).(*Robust.TheilSen)
 	model.Fit()
 
 	resp := map[string]interface{}{
 		"coefficients": model.Coefs,
 		"intercept":    model.Intercept,
+		"outlier_mask": model.OutlierMask,
+		"scale":        model.Scale,
+		"n_subsets":    model.NSubsets,
 		"fit_metrics":  model.GetMetrics(),
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -325,13 +2607,52 @@ func DecTreePostHandler(w http.ResponseWriter, r *http.Request) (err error) {
 	maxFeatures := modelParams.MaxFeatures
 	randomSeed := modelParams.RandomSeed
 
-	model := DecTree.NewDecTree(X, Y, maxDepth, minSamplesSplit, minSamplesLeaf, &randomSeed, &maxFeatures).(*DecTree.DecTree)
+	criterion, err := treeCriterion(modelParams.Criterion)
+	if err != nil {
+		http.Error(w, "Unsupported criterion", http.StatusBadRequest)
+		return
+	}
+	if _, ok := criterion.(DecTree.Poisson); ok && slices.ContainsFunc(Y, func(target float64) bool { return target < 0 }) {
+		http.Error(w, "Poisson criterion requires non-negative targets", http.StatusBadRequest)
+		return
+	}
+
+	var model *DecTree.DecTree
+	if modelParams.Quantile != nil || modelParams.Quantiles != nil {
+		quantile := 0.5
+		if modelParams.Quantile != nil {
+			quantile = *modelParams.Quantile
+		}
+		model = DecTree.NewQuantileDecTree(X, Y, maxDepth, minSamplesSplit, minSamplesLeaf, quantile, &randomSeed, &maxFeatures).(*DecTree.DecTree)
+	} else {
+		model = DecTree.NewDecTree(X, Y, maxDepth, minSamplesSplit, minSamplesLeaf, &randomSeed, &maxFeatures).(*DecTree.DecTree)
+	}
+	model.Criterion = criterion
 	model.Fit()
 
 	resp := map[string]interface{}{
 		"tree_structure":     model.GetTreeString(),
 		"feature_importance": model.GetFeatureImportance(),
-		"fit_metrics":        model.GetMetrics(),
+		"fit_metrics":        nullableMetrics(model.GetMetrics()),
+	}
+	if model.QuantileLeaves {
+		preds := make([]float64, len(X))
+		for i, row := range X {
+			preds[i] = model.Predict(row)
+		}
+		resp["pinball_loss"] = metrics.PinballLoss(Y, preds, model.Quantile)
+	}
+	if modelParams.Quantiles != nil {
+		quantilePreds := make(map[string][]float64, len(modelParams.Quantiles))
+		for _, q := range modelParams.Quantiles {
+			quantilePreds[fmt.Sprintf("%g", q)] = make([]float64, len(X))
+		}
+		for i, row := range X {
+			for j, pred := range model.PredictQuantiles(row, modelParams.Quantiles) {
+				quantilePreds[fmt.Sprintf("%g", modelParams.Quantiles[j])][i] = pred
+			}
+		}
+		resp["quantile_predictions"] = quantilePreds
 	}
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(resp)
@@ -351,23 +2672,82 @@ func BaggedPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
 	baseEstimatorParams := modelParams.BaseEstimatorParams
 	nEstimators := modelParams.NEstimators
 	randomSeed := modelParams.RandomSeed
+	if len(X) == 0 || len(X) != len(Y) {
+		http.Error(w, "X and Y must be non-empty with the same number of rows", http.StatusBadRequest)
+		return
+	}
+
+	maxSamples, bootstrap, maxFeatures := 1.0, true, 1.0
+	if modelParams.MaxSamples != nil {
+		maxSamples = *modelParams.MaxSamples
+	}
+	if modelParams.Bootstrap != nil {
+		bootstrap = *modelParams.Bootstrap
+	}
+	if modelParams.MaxFeatures != nil {
+		maxFeatures = *modelParams.MaxFeatures
+	}
+	if maxSamples <= 0 || maxSamples > 1 || maxFeatures <= 0 || maxFeatures > 1 {
+		http.Error(w, "max_samples and max_features must be in (0, 1]", http.StatusBadRequest)
+		return
+	}
+	alpha, err := modelParams.validate(len(X[0]))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	baseEstimatorFactory, err := ensembleFactoryConstructor(baseEstimatorName, baseEstimatorParams)
 	if err != nil {
-		http.Error(w, "Unsupported base estimator", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	ensemble := Ensemble.NewBagged(baseEstimatorFactory, nEstimators, X, Y, &randomSeed).(*Ensemble.Bagged)
+	bagged := Ensemble.NewSubsampledBagged(baseEstimatorFactory, nEstimators, X, Y, maxSamples, bootstrap, maxFeatures, modelParams.BootstrapFeatures, &randomSeed)
+	if classifier, ok := bagged.(*Ensemble.BaggedClassifier); ok {
+		if modelParams.XPredict != nil {
+			http.Error(w, "X_predict is only supported for regression base estimators", http.StatusBadRequest)
+			return
+		}
+		classifier.NJobs = modelParams.NJobs
+		classifier.Fit()
+
+		estimatorFits := make([]metrics.ClassificationMetrics, nEstimators)
+		for i, est := range classifier.Estimators {
+			estimatorFits[i] = est.(Ensemble.Classifier).GetClassificationMetrics()
+		}
+
+		resp := map[string]interface{}{
+			"classes":                    classifier.Classes,
+			"base_estimator_fit_metrics": estimatorFits,
+			"features":                   bagFeatures(classifier.Bags),
+			"fit_metrics":                classifier.GetClassificationMetrics(),
+			"oob_score":                  classifier.OOBScore,
+			"oob_predictions":            nullableFloats(classifier.OOBPredictions()),
+			"oob_metrics":                classifier.OOBClassificationMetrics,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(resp)
+		return
+	}
+	ensemble := bagged.(*Ensemble.Bagged)
+	ensemble.NJobs = modelParams.NJobs
 	ensemble.Fit()
 
-	estimatorFits := make([]metrics.Metrics, nEstimators)
+	estimatorFits := make([]map[string]*float64, nEstimators)
 	for i, est := range ensemble.Estimators {
-		estimatorFits[i] = est.GetMetrics()
+		estimatorFits[i] = nullableMetrics(est.GetMetrics())
 	}
 
 	resp := map[string]interface{}{
 		"base_estimator_fit_metrics": estimatorFits,
-		"fit_metrics":                ensemble.GetMetrics(),
+		"features":                   bagFeatures(ensemble.Bags),
+		"fit_metrics":                nullableMetrics(ensemble.GetMetrics()),
+		"oob_score":                  ensemble.OOBScore,
+		"oob_predictions":            nullableFloats(ensemble.OOBPredictions()),
+		"oob_metrics":                nullableMetrics(ensemble.OOBMetrics),
+	}
+	if modelParams.XPredict != nil {
+		resp["predictions"] = uncertaintyPredictions(ensemble, modelParams.XPredict, alpha)
 	}
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(resp)
@@ -386,27 +2766,347 @@ func BoostedPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
 	baseEstimatorName := modelParams.BaseEstimator
 	baseEstimatorParams := modelParams.BaseEstimatorParams
 	nEstimators := modelParams.NEstimators
+	if nEstimators == 0 {
+		nEstimators = 10
+	}
 	learningRate := modelParams.LearningRate
+	if learningRate == 0 {
+		learningRate = 0.1
+	}
 
+	if slices.Contains(classifiers, baseEstimatorName) {
+		http.Error(w, "Boosting fits residuals and needs a regression base estimator", http.StatusBadRequest)
+		return
+	}
 	baseEstimatorFactory, err := ensembleFactoryConstructor(baseEstimatorName, baseEstimatorParams)
 	if err != nil {
-		http.Error(w, "Unsupported base estimator", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lossName := modelParams.Loss
+	if lossName == "" {
+		lossName = "squared_error"
+	}
+	alpha := 0.9
+	if modelParams.Alpha != nil {
+		alpha = *modelParams.Alpha
+	}
+	loss, err := Ensemble.LossByName(lossName, alpha)
+	if err != nil {
+		http.Error(w, "Unsupported loss or alpha", http.StatusBadRequest)
+		return
+	}
+
+	subsample, colsampleByTree := 1.0, 1.0
+	if modelParams.Subsample != nil {
+		subsample = *modelParams.Subsample
+	}
+	if modelParams.ColsampleByTree != nil {
+		colsampleByTree = *modelParams.ColsampleByTree
+	}
+	if subsample <= 0 || subsample > 1 || colsampleByTree <= 0 || colsampleByTree > 1 {
+		http.Error(w, "subsample and colsample_bytree must be in (0, 1]", http.StatusBadRequest)
+		return
+	}
+	randomSeed := modelParams.RandomSeed
+
+	validationFraction, nIterNoChange, tol := 0.0, 0, 1e-4
+	if modelParams.ValidationFraction != nil {
+		validationFraction = *modelParams.ValidationFraction
+	}
+	if modelParams.NIterNoChange != nil {
+		nIterNoChange = *modelParams.NIterNoChange
+	}
+	if modelParams.Tol != nil {
+		tol = *modelParams.Tol
+	}
+	if validationFraction < 0 || validationFraction >= 1 {
+		http.Error(w, "validation_fraction must be in [0, 1)", http.StatusBadRequest)
+		return
+	}
+	if len(modelParams.XValidation) != len(modelParams.YValidation) {
+		http.Error(w, "X_validation and Y_validation must have the same number of rows", http.StatusBadRequest)
 		return
 	}
-	ensemble := Ensemble.NewBoosted(baseEstimatorFactory, nEstimators, X, Y, learningRate).(*Ensemble.Boosted)
+	for _, row := range modelParams.XValidation {
+		if len(X) > 0 && len(row) != len(X[0]) {
+			http.Error(w, "X_validation must have as many features as X", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ensemble := Ensemble.NewStochasticBoosted(baseEstimatorFactory, nEstimators, X, Y, learningRate, subsample, colsampleByTree, &randomSeed).(*Ensemble.Boosted)
+	ensemble.Loss = loss
+	if len(modelParams.XValidation) > 0 {
+		ensemble.ValidationX, ensemble.ValidationY = modelParams.XValidation, modelParams.YValidation
+	}
+	ensemble.ValidationFraction = validationFraction
+	ensemble.NIterNoChange = nIterNoChange
+	ensemble.Tol = tol
 	ensemble.Fit()
 
-	// Get nEstimators in case early stopping reduced the number
 	nEstimators = len(ensemble.Estimators)
 
-	estimatorFits := make([]metrics.Metrics, nEstimators)
+	// Stages are fitted on pseudo-residuals, which can be zero or constant, so their metrics are often non-finite
+	estimatorFits := make([]map[string]*float64, nEstimators)
+	for i, est := range ensemble.Estimators {
+		estimatorFits[i] = nullableMetrics(est.GetMetrics())
+	}
+
+	resp := map[string]interface{}{
+		"base_estimator_fit_response": estimatorFits,
+		"loss":                        ensemble.Loss.Name(),
+		"initial_prediction":          ensemble.InitialPrediction,
+		"step_sizes":                  ensemble.StepSizes,
+		"leaf_values":                 ensemble.LeafValues,
+		"features":                    ensemble.Features,
+		"n_estimators_fitted":         nEstimators,
+		"train_loss":                  ensemble.TrainLoss,
+		"validation_loss":             ensemble.ValidationLoss,
+		"fit_metrics":                 nullableMetrics(ensemble.GetMetrics()),
+	}
+	if ensemble.ValidationLoss == nil {
+		resp["validation_loss"] = []float64{}
+	}
+	if ensemble.OOBImprovement != nil {
+		resp["oob_improvement"] = ensemble.OOBImprovement
+		resp["oob_best_n_estimators"] = ensemble.OOBBestNEstimators()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func AdaBoostPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams EnsemblePostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+	baseEstimatorName := modelParams.BaseEstimator
+	baseEstimatorParams := modelParams.BaseEstimatorParams
+	nEstimators := modelParams.NEstimators
+	if nEstimators == 0 {
+		nEstimators = 50
+	}
+	learningRate := modelParams.LearningRate
+	if learningRate == 0 {
+		learningRate = 1.0
+	}
+	loss := modelParams.Loss
+	if loss == "" {
+		loss = Ensemble.AdaBoostLinear
+	}
+	if loss != Ensemble.AdaBoostLinear && loss != Ensemble.AdaBoostSquare && loss != Ensemble.AdaBoostExponential {
+		http.Error(w, "Unsupported loss", http.StatusBadRequest)
+		return
+	}
+	randomSeed := modelParams.RandomSeed
+
+	if slices.Contains(classifiers, baseEstimatorName) {
+		http.Error(w, "AdaBoost.R2 needs a regression base estimator", http.StatusBadRequest)
+		return
+	}
+	baseEstimatorFactory, err := ensembleFactoryConstructor(baseEstimatorName, baseEstimatorParams)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ensemble := Ensemble.NewAdaBoost(baseEstimatorFactory, nEstimators, X, Y, learningRate, loss, &randomSeed).(*Ensemble.AdaBoost)
+	ensemble.WeightedFactory = weightedFactoryConstructor(baseEstimatorName)
+	ensemble.Fit()
+
+	estimatorFits := make([]map[string]*float64, len(ensemble.Estimators))
+	for i, est := range ensemble.Estimators {
+		estimatorFits[i] = nullableMetrics(est.GetMetrics())
+	}
+	sampleWeighting := "resampling"
+	if ensemble.WeightedFactory != nil {
+		sampleWeighting = "reweighting"
+	}
+
+	resp := map[string]interface{}{
+		"base_estimator_fit_response": estimatorFits,
+		"loss":                        ensemble.Loss,
+		"sample_weighting":            sampleWeighting,
+		"estimator_weights":           ensemble.EstimatorWeights,
+		"estimator_errors":            ensemble.EstimatorErrors,
+		"fit_metrics":                 nullableMetrics(ensemble.GetMetrics()),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+// baseEstimatorFactories builds the factories of the base_estimators of a stacked or voting request.
+func baseEstimatorFactories(specs []BaseEstimatorSpec) ([]func(x [][]float64, y []float64) Ensemble.Estimator, error) {
+	if len(specs) == 0 {
+		return nil, errors.New("base_estimators cannot be empty")
+	}
+	factories := make([]func(x [][]float64, y []float64) Ensemble.Estimator, len(specs))
+	for i, spec := range specs {
+		factory, err := specFactory(spec)
+		if err != nil {
+			return nil, err
+		}
+		factories[i] = factory
+	}
+	return factories, nil
+}
+
+func StackedPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams EnsemblePostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+	nFolds := modelParams.NFolds
+	if nFolds == 0 {
+		nFolds = 5
+	}
+	if nFolds < 2 || nFolds > len(Y) {
+		http.Error(w, "n_folds must be in [2, number of rows]", http.StatusBadRequest)
+		return
+	}
+	metaEstimatorName := modelParams.MetaEstimator
+	if metaEstimatorName == "" {
+		metaEstimatorName = "ridge"
+	}
+	randomSeed := modelParams.RandomSeed
+
+	factories, err := baseEstimatorFactories(modelParams.BaseEstimators)
+	if err != nil {
+		http.Error(w, "Unsupported base estimators: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if slices.Contains(classifiers, metaEstimatorName) {
+		http.Error(w, "The meta estimator must be a regression estimator", http.StatusBadRequest)
+		return
+	}
+	metaFactory, err := ensembleFactoryConstructor(metaEstimatorName, modelParams.MetaEstimatorParams)
+	if err != nil {
+		http.Error(w, "meta_estimator: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ensemble := Ensemble.NewStacked(factories, metaFactory, X, Y, nFolds, &randomSeed).(*Ensemble.Stacked)
+	ensemble.Fit()
+
+	estimatorFits := make([]map[string]*float64, len(ensemble.Estimators))
+	for i, est := range ensemble.Estimators {
+		estimatorFits[i] = nullableMetrics(est.GetMetrics())
+	}
+
+	resp := map[string]interface{}{
+		"base_estimator_fit_response": estimatorFits,
+		"base_estimator_oof_metrics":  nullableMetricsList(ensemble.BaseOOFMetrics),
+		"oof_metrics":                 nullableMetrics(ensemble.OOFMetrics),
+		"fit_metrics":                 nullableMetrics(ensemble.GetMetrics()),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func VotingPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams EnsemblePostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+	weights := modelParams.Weights
+
+	factories, err := baseEstimatorFactories(modelParams.BaseEstimators)
+	if err != nil {
+		http.Error(w, "Unsupported base estimators: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if weights != nil {
+		if len(weights) != len(factories) {
+			http.Error(w, "weights must have one entry per base estimator", http.StatusBadRequest)
+			return
+		}
+		sum := 0.0
+		for _, weight := range weights {
+			if weight < 0 {
+				http.Error(w, "weights cannot be negative", http.StatusBadRequest)
+				return
+			}
+			sum += weight
+		}
+		if sum == 0 {
+			http.Error(w, "weights cannot all be zero", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ensemble := Ensemble.NewVoting(factories, X, Y, weights).(*Ensemble.Voting)
+	ensemble.Fit()
+
+	estimatorFits := make([]map[string]*float64, len(ensemble.Estimators))
 	for i, est := range ensemble.Estimators {
-		estimatorFits[i] = est.GetMetrics()
+		estimatorFits[i] = nullableMetrics(est.GetMetrics())
 	}
 
 	resp := map[string]interface{}{
 		"base_estimator_fit_response": estimatorFits,
-		"fit_metrics":                 ensemble.GetMetrics(),
+		"fit_metrics":                 nullableMetrics(ensemble.GetMetrics()),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	return
+}
+
+func DiagnosticsPostHandler(w http.ResponseWriter, r *http.Request) (err error) {
+	var modelParams DiagnosticsPostBody
+	err = json.NewDecoder(r.Body).Decode(&modelParams)
+	if err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	X := modelParams.X
+	Y := modelParams.Y
+
+	var report diagnostics.Report
+	var summary string
+	switch {
+	case modelParams.Residuals != nil:
+		if len(modelParams.Residuals) != len(X) {
+			return errors.New("residuals must have one entry per row of X")
+		}
+		fitIntercept := true
+		if modelParams.FitIntercept != nil {
+			fitIntercept = *modelParams.FitIntercept
+		}
+		report = diagnostics.FromResiduals(X, modelParams.Residuals, fitIntercept)
+		summary = report.String()
+	case modelParams.Model == "" || modelParams.Model == "ols":
+		model := OLS.NewOLS(X, Y).(*OLS.OLS)
+		model.Fit()
+		report = diagnostics.FromOLS(model)
+		summary = model.Summary() + report.String()
+	case modelParams.Model == "linreg":
+		model := LinReg.NewLinReg(X, Y).(*LinReg.LinReg)
+		model.Fit()
+		report = diagnostics.FromLinReg(model)
+		summary = model.Summary() + report.String()
+	default:
+		return errors.New("unsupported model, expected 'ols' or 'linreg'")
+	}
+
+	resp := map[string]interface{}{
+		"diagnostics": report,
+		"summary":     summary,
 	}
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(resp)
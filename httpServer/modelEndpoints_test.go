@@ -0,0 +1,130 @@
+package httpServer
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// post sends body to a POST handler and decodes its JSON response, failing on any handler or encoding error.
+func post(t *testing.T, handler func(w http.ResponseWriter, r *http.Request) error, body interface{}) map[string]interface{} {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	if err := handler(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))); err != nil {
+		t.Fatalf("handler failed: %v", err)
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response %q: %v", w.Body.String(), err)
+	}
+	return resp
+}
+
+// The median row of an odd number of rows has a zero absolute error or Huber pseudo-residual, so the first stage
+// is fitted on a zero target and its MAPE is infinite.
+func TestBoostedZeroPseudoResiduals(t *testing.T) {
+	x := make([][]float64, 121)
+	y := make([]float64, 121)
+	for i := range x {
+		x[i] = []float64{float64(i)}
+		y[i] = 10 + math.Sin(float64(i))
+	}
+	for _, loss := range []string{"absolute_error", "huber"} {
+		t.Run(loss, func(t *testing.T) {
+			resp := post(t, BoostedPostHandler, map[string]interface{}{
+				"X":                     x,
+				"Y":                     y,
+				"base_estimator":        "dectree",
+				"base_estimator_params": map[string]interface{}{"max_depth": 3},
+				"loss":                  loss,
+				"n_estimators":          5,
+			})
+			stage := resp["base_estimator_fit_response"].([]interface{})[0].(map[string]interface{})
+			if stage["mape"] != nil {
+				t.Errorf("stage MAPE %v, want null", stage["mape"])
+			}
+		})
+	}
+}
+
+// Count data for the Poisson criterion usually has zeros, whose MAPE is infinite.
+func TestPoissonZeroCounts(t *testing.T) {
+	x := [][]float64{{0}, {1}, {2}, {3}, {4}, {5}, {6}, {7}}
+	y := []float64{0, 0, 1, 2, 0, 3, 5, 4}
+	tree := map[string]interface{}{"X": x, "Y": y, "criterion": "poisson", "random_seed": 1}
+	ensemble := map[string]interface{}{
+		"X":                     x,
+		"Y":                     y,
+		"base_estimator":        "dectree",
+		"base_estimator_params": map[string]interface{}{"criterion": "poisson"},
+		"n_estimators":          5,
+		"random_seed":           1,
+	}
+	specs := map[string]interface{}{
+		"X":               x,
+		"Y":               y,
+		"base_estimators": []interface{}{map[string]interface{}{"base_estimator": "dectree", "base_estimator_params": map[string]interface{}{"criterion": "poisson"}}},
+		"n_folds":         2,
+	}
+	cases := []struct {
+		name    string
+		handler func(w http.ResponseWriter, r *http.Request) error
+		body    map[string]interface{}
+	}{
+		{"dectree", DecTreePostHandler, tree},
+		{"randomforest", RandomForestPostHandler, tree},
+		{"extratrees", ExtraTreesPostHandler, tree},
+		{"bagged", BaggedPostHandler, ensemble},
+		{"adaboost", AdaBoostPostHandler, ensemble},
+		{"conformal", ConformalPostHandler, ensemble},
+		{"stacked", StackedPostHandler, specs},
+		{"voting", VotingPostHandler, specs},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := post(t, tt.handler, tt.body)
+			if mape := resp["fit_metrics"].(map[string]interface{})["mape"]; mape != nil {
+				t.Errorf("MAPE %v, want null", mape)
+			}
+		})
+	}
+}
+
+// A max_features of 0 selects the default of the forest, anything else outside [1, number of features] is rejected.
+func TestForestMaxFeatures(t *testing.T) {
+	x := make([][]float64, 20)
+	y := make([]float64, 20)
+	for i := range x {
+		x[i] = []float64{float64(i), float64(i % 3)}
+		y[i] = float64(i)
+	}
+	body := map[string]interface{}{"X": x, "Y": y, "n_estimators": 3, "random_seed": 1}
+
+	body["max_features"] = 0
+	if got := post(t, RandomForestPostHandler, body)["max_features"]; got != 1.0 {
+		t.Errorf("default max_features %v, want 1", got)
+	}
+
+	for _, maxFeatures := range []int{-1, 3} {
+		body["max_features"] = maxFeatures
+		payload, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		if err := RandomForestPostHandler(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "0 for the default") {
+			t.Errorf("max_features %d: status %d %q, want a bad request naming the default", maxFeatures, w.Code, w.Body.String())
+		}
+	}
+}
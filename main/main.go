@@ -1,7 +1,10 @@
 package main
 
 import (
+	"GoML/LinReg"
+	"GoML/OLS"
 	"GoML/demo"
+	"GoML/diagnostics"
 	"GoML/httpServer"
 	"GoML/parser"
 	"flag"
@@ -13,8 +16,11 @@ import (
 
 func flowUsage() {
 	fmt.Println("Usage:")
-	fmt.Println("model names: linreg, ols, dectree")
-	fmt.Println("ensemble methods: bagged, boosted")
+	fmt.Println("model names: linreg, ols, dectree, ridge, lasso, elasticnet, ridgecv, lassocv, elasticnetcv, huber, ransac, theilsen, quantreg, poisson, gamma, tweedie, histgb, randomforest, extratrees")
+	fmt.Println("classifier names (Y holds class labels): logistic, dectreeclassifier")
+	fmt.Println("classifiers support the bagged ensemble method only")
+	fmt.Println("ensemble methods: bagged, boosted, adaboost, conformal")
+	fmt.Println("conformal predicts 90% intervals, calibrated with CV+ over nEstimators folds (>1) or on a held-out split (1)")
 	fmt.Println("nEstimators must be an integer (>0)")
 	fmt.Println("Accepted y/n inputs (case insensitive): y, yes, n, no")
 
@@ -27,14 +33,39 @@ func panicUsage(usage func()) {
 }
 
 var models = map[string]struct{}{
-	"linreg":  {},
-	"ols":     {},
-	"dectree": {},
+	"linreg":            {},
+	"ols":               {},
+	"dectree":           {},
+	"ridge":             {},
+	"lasso":             {},
+	"elasticnet":        {},
+	"ridgecv":           {},
+	"lassocv":           {},
+	"elasticnetcv":      {},
+	"huber":             {},
+	"ransac":            {},
+	"theilsen":          {},
+	"quantreg":          {},
+	"poisson":           {},
+	"gamma":             {},
+	"tweedie":           {},
+	"histgb":            {},
+	"randomforest":      {},
+	"extratrees":        {},
+	"logistic":          {},
+	"dectreeclassifier": {},
+}
+
+var classifiers = map[string]struct{}{
+	"logistic":          {},
+	"dectreeclassifier": {},
 }
 
 var ensembles = map[string]struct{}{
-	"bagged":  {},
-	"boosted": {},
+	"bagged":    {},
+	"boosted":   {},
+	"adaboost":  {},
+	"conformal": {},
 }
 
 func mainLoop(filePath string, hasHeaders bool, targetIndex int) {
@@ -59,7 +90,7 @@ func mainLoop(filePath string, hasHeaders bool, targetIndex int) {
 		var nEstimators int
 		var reRun string
 
-		fmt.Println("Enter Model Name (linreg, ols, dectree): ")
+		fmt.Println("Enter Model Name (linreg, ols, dectree, ridge, lasso, elasticnet, ridgecv, lassocv, elasticnetcv, huber, ransac, theilsen, quantreg, poisson, gamma, tweedie, histgb, randomforest, extratrees, logistic, dectreeclassifier): ")
 		_, err = fmt.Scanln(&modelName)
 		if err != nil || strings.TrimSpace(modelName) == "" {
 			panicUsage(flowUsage)
@@ -75,7 +106,7 @@ func mainLoop(filePath string, hasHeaders bool, targetIndex int) {
 		}
 		if strings.ToLower(ensembleYN) == "y" || strings.ToLower(ensembleYN) == "yes" {
 			isEnsemble = true
-			fmt.Println("Enter Ensemble Method (bagged, boosted): ")
+			fmt.Println("Enter Ensemble Method (bagged, boosted, adaboost, conformal): ")
 			_, err = fmt.Scanln(&ensembleMethod)
 			if err != nil || strings.TrimSpace(ensembleMethod) == "" {
 				panicUsage(flowUsage)
@@ -83,6 +114,9 @@ func mainLoop(filePath string, hasHeaders bool, targetIndex int) {
 			if _, ok := ensembles[strings.ToLower(ensembleMethod)]; !ok {
 				panicUsage(flowUsage)
 			}
+			if _, ok := classifiers[strings.ToLower(modelName)]; ok && strings.ToLower(ensembleMethod) != "bagged" {
+				panicUsage(flowUsage)
+			}
 
 			fmt.Println("Enter Number of Estimators (>0): ")
 			_, err = fmt.Scanln(&nEstimators)
@@ -114,17 +148,43 @@ func mainLoop(filePath string, hasHeaders bool, targetIndex int) {
 
 }
 
+func runDiagnostics(filePath string, hasHeaders bool, targetIndex int, modelName string) {
+	data := parser.LoadData(filePath, ",", hasHeaders, targetIndex)
+	fmt.Printf("Data Loaded: %d samples, %d features\n", len(data.X), len(data.X[0]))
+	fmt.Printf("Feature Names: %v\n", data.FeatureNames)
+	fmt.Printf("Target Name: %s\n", data.TargetName)
+
+	switch strings.ToLower(modelName) {
+	case "ols":
+		model := OLS.NewOLS(data.X, data.Y).(*OLS.OLS)
+		model.Fit()
+		fmt.Println(model.Summary())
+		fmt.Println(diagnostics.FromOLS(model))
+	case "linreg":
+		model := LinReg.NewLinReg(data.X, data.Y).(*LinReg.LinReg)
+		model.Fit()
+		fmt.Println(model.Summary())
+		fmt.Println(diagnostics.FromLinReg(model))
+	default:
+		panicUsage(flag.Usage)
+	}
+}
+
 func main() {
 	flag.Usage = func() {
 		fmt.Println("Usage with Flags:")
 		flag.PrintDefaults()
 		fmt.Println("\nUsage with Args:")
 		fmt.Println("go run main.go <string file_path> [<bool hasHeaders>] <int target_index>")
+		fmt.Println("\nSubcommands:")
+		fmt.Println("go run main.go [flags] diagnostics <string file_path> [<bool hasHeaders>] <int target_index>")
+		fmt.Println("    Fits the model chosen with -diagnostics-model and prints its regression diagnostics.")
 	}
 	var demoFlag = flag.Bool("serve", false, "<bool> Serve HTTP demo over localhost. All other flags will be ignored if true. (default false)")
 	var filePathFlag = flag.String("data-csv", "", "<string> Path to CSV data file")
 	var hasHeadersFlag = flag.Bool("h", false, "<bool> Whether the CSV file has headers")
 	var targetIndexFlag = flag.Int("target-index", -1, "<int> Index of the target column (0-based)")
+	var diagnosticsModelFlag = flag.String("diagnostics-model", "ols", "<string> Model fitted by the diagnostics subcommand: ols or linreg")
 
 	var filePath string
 	var hasHeaders bool
@@ -141,6 +201,10 @@ func main() {
 	}
 
 	args := flag.Args()
+	isDiagnostics := len(args) > 0 && strings.ToLower(args[0]) == "diagnostics"
+	if isDiagnostics {
+		args = args[1:]
+	}
 	switch len(args) {
 	case 2:
 		filePath = args[0]
@@ -187,5 +251,9 @@ func main() {
 		}
 	}
 
+	if isDiagnostics {
+		runDiagnostics(filePath, hasHeaders, targetIndex, *diagnosticsModelFlag)
+		return
+	}
 	mainLoop(filePath, hasHeaders, targetIndex)
 }
@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// ClassificationMetrics summarizes the fit of a classifier. Binary precision, recall and F1 treat the larger
+// class label as positive; with more than two classes they (and ROC-AUC) are macro averages over one-vs-rest problems.
+type ClassificationMetrics struct {
+	Accuracy  float64 `json:"accuracy"`
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+	LogLoss   float64 `json:"log_loss"`
+	ROCAUC    float64 `json:"roc_auc"`
+
+	Classes         []float64 `json:"classes"`
+	ConfusionMatrix [][]int   `json:"confusion_matrix"` // Rows are true classes, columns predicted classes, both in Classes order
+}
+
+// UniqueClasses returns the sorted distinct labels of y.
+func UniqueClasses(y []float64) []float64 {
+	classes := slices.Clone(y)
+	slices.Sort(classes)
+	return slices.Compact(classes)
+}
+
+// ArgMax returns the index of the largest of values, the first one on ties, so that the predicted class of a
+// probability vector in classes order is classes[ArgMax(proba)].
+func ArgMax(values []float64) int {
+	best := 0
+	for k, val := range values {
+		if val > values[best] {
+			best = k
+		}
+	}
+	return best
+}
+
+// ConfusionMatrix counts rows by true (row) and predicted (column) class. Labels missing from classes are ignored.
+func ConfusionMatrix(yTrue, yPred, classes []float64) [][]int {
+	matrix := make([][]int, len(classes))
+	for i := range matrix {
+		matrix[i] = make([]int, len(classes))
+	}
+	for i := range yTrue {
+		t, okTrue := slices.BinarySearch(classes, yTrue[i])
+		p, okPred := slices.BinarySearch(classes, yPred[i])
+		if okTrue && okPred {
+			matrix[t][p]++
+		}
+	}
+	return matrix
+}
+
+func Accuracy(yTrue, yPred []float64) float64 {
+	correct := 0
+	for i := range yTrue {
+		if yTrue[i] == yPred[i] {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(yTrue))
+}
+
+// PrecisionRecallF1 computes the scores from a confusion matrix. Two classes give the scores of the second (positive)
+// class, more give their macro average. Undefined ratios (e.g. a class that is never predicted) count as 0.
+func PrecisionRecallF1(confusion [][]int) (precision, recall, f1 float64) {
+	ratio := func(num, den int) float64 {
+		if den == 0 {
+			return 0
+		}
+		return float64(num) / float64(den)
+	}
+	classScores := func(k int) (p, r, f float64) {
+		predicted, actual := 0, 0
+		for i := range confusion {
+			predicted += confusion[i][k]
+			actual += confusion[k][i]
+		}
+		p = ratio(confusion[k][k], predicted)
+		r = ratio(confusion[k][k], actual)
+		if p+r > 0 {
+			f = 2 * p * r / (p + r)
+		}
+		return
+	}
+
+	if len(confusion) == 2 {
+		return classScores(1)
+	}
+	for k := range confusion {
+		p, r, f := classScores(k)
+		precision += p / float64(len(confusion))
+		recall += r / float64(len(confusion))
+		f1 += f / float64(len(confusion))
+	}
+	return
+}
+
+// LogLoss is the mean negative log-likelihood of the true classes, with proba[i][k] the predicted probability of
+// classes[k] for row i. Probabilities are clipped to [1e-15, 1 - 1e-15] to keep the loss finite.
+func LogLoss(yTrue []float64, proba [][]float64, classes []float64) float64 {
+	loss := 0.0
+	for i, label := range yTrue {
+		k, ok := slices.BinarySearch(classes, label)
+		if !ok {
+			panic("LogLoss: label is not one of the classes")
+		}
+		p := math.Min(math.Max(proba[i][k], 1e-15), 1-1e-15)
+		loss -= math.Log(p)
+	}
+	return loss / float64(len(yTrue))
+}
+
+// ROCAUC is the area under the ROC curve of scores for separating rows labelled positive from the rest, computed as
+// the probability that a random positive row scores above a random negative one (ties count half).
+// It returns NaN when only one of the two groups is present.
+func ROCAUC(yTrue, scores []float64, positive float64) float64 {
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return scores[order[a]] < scores[order[b]] })
+
+	// Sum the (tie averaged) ranks of the positive rows
+	nPos, nNeg := 0, 0
+	rankSum := 0.0
+	for start := 0; start < len(order); {
+		end := start
+		for end < len(order) && scores[order[end]] == scores[order[start]] {
+			end++
+		}
+		avgRank := float64(start+end+1) / 2
+		for _, idx := range order[start:end] {
+			if yTrue[idx] == positive {
+				nPos++
+				rankSum += avgRank
+			} else {
+				nNeg++
+			}
+		}
+		start = end
+	}
+	if nPos == 0 || nNeg == 0 {
+		return math.NaN()
+	}
+	return (rankSum - float64(nPos*(nPos+1))/2) / float64(nPos*nNeg)
+}
+
+// EvaluateClassifier computes every classification metric from predicted labels and class probabilities, with
+// proba[i][k] the probability of classes[k] for row i. When ROC-AUC is undefined for every class (the rows hold a
+// single class) it is reported as 0.5, the score of an uninformative classifier.
+func EvaluateClassifier(yTrue, yPred []float64, proba [][]float64, classes []float64) ClassificationMetrics {
+	confusion := ConfusionMatrix(yTrue, yPred, classes)
+	precision, recall, f1 := PrecisionRecallF1(confusion)
+
+	scores := make([]float64, len(yTrue))
+	classScores := func(k int) []float64 {
+		for i := range proba {
+			scores[i] = proba[i][k]
+		}
+		return scores
+	}
+	auc := math.NaN()
+	if len(classes) == 2 {
+		auc = ROCAUC(yTrue, classScores(1), classes[1])
+	} else {
+		sum, n := 0.0, 0
+		for k, class := range classes {
+			if classAUC := ROCAUC(yTrue, classScores(k), class); !math.IsNaN(classAUC) {
+				sum += classAUC
+				n++
+			}
+		}
+		if n > 0 {
+			auc = sum / float64(n)
+		}
+	}
+	if math.IsNaN(auc) {
+		auc = 0.5
+	}
+
+	return ClassificationMetrics{
+		Accuracy:        Accuracy(yTrue, yPred),
+		Precision:       precision,
+		Recall:          recall,
+		F1:              f1,
+		LogLoss:         LogLoss(yTrue, proba, classes),
+		ROCAUC:          auc,
+		Classes:         classes,
+		ConfusionMatrix: confusion,
+	}
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func assertClose(t *testing.T, name string, got, want, tol float64) {
+	t.Helper()
+	if math.Abs(got-want) > tol {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestAccuracyAndConfusion(t *testing.T) {
+	yTrue := []float64{0, 1, 1, 2, 2, 2}
+	yPred := []float64{0, 1, 2, 2, 2, 0}
+	assertClose(t, "accuracy", Accuracy(yTrue, yPred), 4.0/6, 1e-15)
+
+	confusion := ConfusionMatrix(yTrue, yPred, []float64{0, 1, 2})
+	want := [][]int{{1, 0, 0}, {0, 1, 1}, {1, 0, 2}}
+	if !slices.EqualFunc(confusion, want, slices.Equal) {
+		t.Errorf("confusion matrix %v, want %v", confusion, want)
+	}
+}
+
+func TestArgMax(t *testing.T) {
+	for _, tc := range []struct {
+		values []float64
+		want   int
+	}{
+		{[]float64{0.2, 0.5, 0.3}, 1},
+		{[]float64{0.4, 0.2, 0.4}, 0},
+		{[]float64{0.1, 0.45, 0.45}, 1},
+		{[]float64{1}, 0},
+	} {
+		if got := ArgMax(tc.values); got != tc.want {
+			t.Errorf("ArgMax(%v) = %d, want %d", tc.values, got, tc.want)
+		}
+	}
+}
+
+func TestPrecisionRecallF1(t *testing.T) {
+	// Binary scores are those of the second class: 2 of 3 predicted positives and 2 of 4 actual positives are right
+	precision, recall, f1 := PrecisionRecallF1([][]int{{2, 1}, {2, 2}})
+	assertClose(t, "precision", precision, 2.0/3, 1e-15)
+	assertClose(t, "recall", recall, 0.5, 1e-15)
+	assertClose(t, "f1", f1, 2*(2.0/3)*0.5/(2.0/3+0.5), 1e-15)
+
+	// Macro average over three classes, the third never predicted scores 0
+	precision, recall, f1 = PrecisionRecallF1([][]int{{1, 0, 0}, {1, 2, 0}, {1, 1, 0}})
+	assertClose(t, "macro precision", precision, (1.0/3+2.0/3+0)/3, 1e-15)
+	assertClose(t, "macro recall", recall, (1+2.0/3+0)/3, 1e-15)
+	assertClose(t, "macro f1", f1, (0.5+2.0/3+0)/3, 1e-15)
+}
+
+func TestLogLoss(t *testing.T) {
+	classes := []float64{0, 1}
+	assertClose(t, "log loss", LogLoss([]float64{0, 1}, [][]float64{{0.8, 0.2}, {0.3, 0.7}}, classes), -(math.Log(0.8)+math.Log(0.7))/2, 1e-15)
+	// A zero probability on the true class is clipped to 1e-15
+	assertClose(t, "clipped log loss", LogLoss([]float64{1}, [][]float64{{1, 0}}, classes), -math.Log(1e-15), 1e-12)
+}
+
+func TestROCAUC(t *testing.T) {
+	// Of the four positive-negative pairs, only (0.35, 0.4) is ordered wrong
+	assertClose(t, "auc", ROCAUC([]float64{0, 0, 1, 1}, []float64{0.1, 0.4, 0.35, 0.8}, 1), 0.75, 1e-15)
+	// The positive at 0.5 ties both negatives (half each), the one at 0.9 beats both
+	assertClose(t, "tied auc", ROCAUC([]float64{0, 1, 0, 1}, []float64{0.5, 0.5, 0.5, 0.9}, 1), 0.75, 1e-15)
+	assertClose(t, "all tied auc", ROCAUC([]float64{0, 1, 0, 1}, []float64{0.5, 0.5, 0.5, 0.5}, 1), 0.5, 1e-15)
+	if auc := ROCAUC([]float64{1, 1}, []float64{0.2, 0.9}, 1); !math.IsNaN(auc) {
+		t.Errorf("auc with a single group %v, want NaN", auc)
+	}
+}
+
+func TestEvaluateClassifier(t *testing.T) {
+	classes := []float64{0, 1}
+	proba := [][]float64{{0.9, 0.1}, {0.6, 0.4}, {0.3, 0.7}, {0.2, 0.8}}
+	m := EvaluateClassifier([]float64{0, 1, 0, 1}, []float64{0, 0, 1, 1}, proba, classes)
+	assertClose(t, "accuracy", m.Accuracy, 0.5, 1e-15)
+	assertClose(t, "auc", m.ROCAUC, 0.75, 1e-15)
+	assertClose(t, "log loss", m.LogLoss, -(math.Log(0.9)+math.Log(0.4)+math.Log(0.3)+math.Log(0.8))/4, 1e-15)
+
+	// ROC-AUC is undefined with a single class in the rows and falls back to 0.5
+	m = EvaluateClassifier([]float64{1, 1}, []float64{1, 0}, [][]float64{{0.2, 0.8}, {0.6, 0.4}}, classes)
+	assertClose(t, "single class auc", m.ROCAUC, 0.5, 0)
+
+	// Multiclass AUC averages the one-vs-rest AUCs of the classes present in the rows: class 0 scores 1, class 1
+	// scores 0.5 (its positive at 0.5 beats the negative at 0.2, the one at 0.1 does not) and class 2 is absent
+	multiProba := [][]float64{{0.7, 0.2, 0.1}, {0.4, 0.5, 0.1}, {0.5, 0.1, 0.4}}
+	m = EvaluateClassifier([]float64{0, 1, 1}, []float64{0, 1, 0}, multiProba, []float64{0, 1, 2})
+	assertClose(t, "multiclass auc", m.ROCAUC, 0.75, 1e-15)
+}
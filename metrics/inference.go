@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Inference holds the classical least squares inference of a linear fit.
+// Per-parameter slices follow the order of the design matrix, so models with an intercept
+// report it at index 0 followed by the feature coefficients.
+type Inference struct {
+	StdErrors []float64    `json:"std_errors"`
+	TValues   []float64    `json:"t_values"`
+	PValues   []float64    `json:"p_values"`
+	ConfInt   [][2]float64 `json:"conf_int"` // 95% confidence interval per parameter
+
+	NObs    int `json:"n_obs"`
+	DFModel int `json:"df_model"`
+	DFResid int `json:"df_resid"`
+
+	R2            float64 `json:"r2"`
+	AdjR2         float64 `json:"adj_r2"`
+	FStatistic    float64 `json:"f_statistic"`
+	FPValue       float64 `json:"f_p_value"`
+	LogLikelihood float64 `json:"log_likelihood"`
+	AIC           float64 `json:"aic"`
+	BIC           float64 `json:"bic"`
+
+	hasIntercept bool
+	params       []float64
+}
+
+// Infer computes standard errors and fit statistics of a least squares solution beta of the system X*beta = y,
+// from the thin SVD of the design matrix X and its numerical rank.
+// Covariance of beta is estimated as sigma^2 * pinv(X^T X) so rank deficient designs are handled like the fit itself.
+// R2 is centered when hasIntercept is set and uncentered otherwise.
+// For weighted least squares, svd must factorize the weighted design diag(sqrt(w)) * X while y and preds stay on
+// the original scale; a nil weights slice weighs every row equally.
+func Infer(svd *mat.SVD, rank int, y, preds, beta, weights []float64, hasIntercept bool) Inference {
+	nObs, nParams := len(y), len(beta)
+	dfModel := rank
+	if hasIntercept {
+		dfModel--
+	}
+	dfResid := nObs - rank
+
+	inf := Inference{
+		NObs:         nObs,
+		DFModel:      dfModel,
+		DFResid:      dfResid,
+		hasIntercept: hasIntercept,
+		params:       beta,
+	}
+
+	weight := func(i int) float64 {
+		if weights == nil {
+			return 1.0
+		}
+		return weights[i]
+	}
+
+	SSR := 0.0
+	SST := 0.0
+	yMean := 0.0
+	if hasIntercept {
+		sumWeights := 0.0
+		for i, val := range y {
+			yMean += weight(i) * val
+			sumWeights += weight(i)
+		}
+		yMean /= sumWeights
+	}
+	logWeights := 0.0
+	for i := range y {
+		SSR += weight(i) * (y[i] - preds[i]) * (y[i] - preds[i])
+		SST += weight(i) * (y[i] - yMean) * (y[i] - yMean)
+		logWeights += math.Log(weight(i))
+	}
+
+	n := float64(nObs)
+	inf.LogLikelihood = -n/2*(math.Log(2*math.Pi)+math.Log(SSR/n)+1) + logWeights/2
+	inf.AIC = -2*inf.LogLikelihood + 2*float64(rank)
+	inf.BIC = -2*inf.LogLikelihood + math.Log(n)*float64(rank)
+	inf.R2 = 1 - SSR/SST
+
+	if dfResid <= 0 {
+		// Saturated fit, the residual variance (and everything derived from it) is undefined
+		return inf
+	}
+
+	sigma2 := SSR / float64(dfResid)
+	inf.AdjR2 = 1 - (1-inf.R2)*float64(nObs-boolToInt(hasIntercept))/float64(dfResid)
+	if dfModel > 0 {
+		// Rounding can leave SSR a hair above SST when the features explain nothing
+		inf.FStatistic = math.Max(0, (SST-SSR)/float64(dfModel)) / sigma2
+		inf.FPValue = 1 - distuv.F{D1: float64(dfModel), D2: float64(dfResid)}.CDF(inf.FStatistic)
+	}
+
+	// diag(pinv(X^T X)) = sum_k V_jk^2 / s_k^2 over the first rank singular values
+	var v mat.Dense
+	svd.VTo(&v)
+	singularValues := svd.Values(nil)
+
+	tDist := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(dfResid)}
+	tCrit := tDist.Quantile(0.975)
+
+	inf.StdErrors = make([]float64, nParams)
+	inf.TValues = make([]float64, nParams)
+	inf.PValues = make([]float64, nParams)
+	inf.ConfInt = make([][2]float64, nParams)
+	for j := 0; j < nParams; j++ {
+		variance := 0.0
+		for k := 0; k < rank; k++ {
+			variance += v.At(j, k) * v.At(j, k) / (singularValues[k] * singularValues[k])
+		}
+		se := math.Sqrt(sigma2 * variance)
+		inf.StdErrors[j] = se
+
+		if se == 0 {
+			// Parameter is not identified by the data (e.g. constant zero column)
+			inf.TValues[j] = 0
+			inf.PValues[j] = 1
+		} else {
+			inf.TValues[j] = beta[j] / se
+			inf.PValues[j] = 2 * (1 - tDist.CDF(math.Abs(inf.TValues[j])))
+		}
+		inf.ConfInt[j] = [2]float64{beta[j] - tCrit*se, beta[j] + tCrit*se}
+	}
+	return inf
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Summary renders the inference as a text table. names labels the parameters in design matrix order;
+// when nil, features are labelled x0, x1, ... (preceded by const for models with an intercept).
+func (inf Inference) Summary(title string, names []string) string {
+	if names == nil {
+		names = make([]string, 0, len(inf.params))
+		if inf.hasIntercept {
+			names = append(names, "const")
+		}
+		for j := len(names); j < len(inf.params); j++ {
+			names = append(names, fmt.Sprintf("x%d", j-boolToInt(inf.hasIntercept)))
+		}
+	}
+
+	width := 78
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%*s\n", (width+len(title))/2, title))
+	sb.WriteString(strings.Repeat("=", width) + "\n")
+	sb.WriteString(fmt.Sprintf("%-22s%16d    %-22s%14.4f\n", "No. Observations:", inf.NObs, "R-squared:", inf.R2))
+	sb.WriteString(fmt.Sprintf("%-22s%16d    %-22s%14.4f\n", "Df Residuals:", inf.DFResid, "Adj. R-squared:", inf.AdjR2))
+	sb.WriteString(fmt.Sprintf("%-22s%16d    %-22s%14.4f\n", "Df Model:", inf.DFModel, "F-statistic:", inf.FStatistic))
+	sb.WriteString(fmt.Sprintf("%-22s%16.4f    %-22s%14.4g\n", "Log-Likelihood:", inf.LogLikelihood, "Prob (F-statistic):", inf.FPValue))
+	sb.WriteString(fmt.Sprintf("%-22s%16.4f    %-22s%14.4f\n", "AIC:", inf.AIC, "BIC:", inf.BIC))
+	sb.WriteString(strings.Repeat("=", width) + "\n")
+
+	if inf.StdErrors == nil {
+		sb.WriteString("Standard errors unavailable: the model has no residual degrees of freedom.\n")
+		sb.WriteString(strings.Repeat("=", width) + "\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("%-12s%11s%11s%11s%11s%11s%11s\n", "", "coef", "std err", "t", "P>|t|", "[0.025", "0.975]"))
+	sb.WriteString(strings.Repeat("-", width) + "\n")
+	for j, name := range names {
+		sb.WriteString(fmt.Sprintf("%-12s%11.4f%11.4f%11.3f%11.3f%11.4f%11.4f\n",
+			name, inf.params[j], inf.StdErrors[j], inf.TValues[j], inf.PValues[j], inf.ConfInt[j][0], inf.ConfInt[j][1]))
+	}
+	sb.WriteString(strings.Repeat("=", width) + "\n")
+	return sb.String()
+}
@@ -15,6 +15,11 @@ type Metrics struct {
 }
 
 func Evaluate(yTrue, yPred []float64) Metrics {
+	return EvaluateWeighted(yTrue, yPred, nil)
+}
+
+// EvaluateWeighted computes the metrics with per-row sample weights. A nil weights slice weighs every row equally.
+func EvaluateWeighted(yTrue, yPred, weights []float64) Metrics {
 	var R2, MSE, RMSE, MAE, MAPE float64
 
 	SSR := 0.0
@@ -23,24 +28,36 @@ func Evaluate(yTrue, yPred []float64) Metrics {
 	APE := 0.0
 
 	nRows := len(yTrue)
-	yMean := stat.Mean(yTrue, nil)
+	yMean := stat.Mean(yTrue, weights)
+
+	sumWeights := float64(nRows)
+	if weights != nil {
+		sumWeights = 0.0
+		for _, w := range weights {
+			sumWeights += w
+		}
+	}
 
 	for i := 0; i < nRows; i++ {
-		SSR += math.Pow(yPred[i]-yTrue[i], 2)
-		SST += math.Pow(yTrue[i]-yMean, 2)
-		AE += math.Abs(yPred[i] - yTrue[i])
-		APE += math.Abs((yPred[i] - yTrue[i]) / yTrue[i])
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		SSR += w * math.Pow(yPred[i]-yTrue[i], 2)
+		SST += w * math.Pow(yTrue[i]-yMean, 2)
+		AE += w * math.Abs(yPred[i]-yTrue[i])
+		APE += w * math.Abs((yPred[i]-yTrue[i])/yTrue[i])
 	}
 
 	//R2
 	R2 = 1 - (SSR / SST)
 
 	//MSE
-	MSE = SSR / float64(nRows)
+	MSE = SSR / sumWeights
 	RMSE = math.Sqrt(MSE)
 
-	MAE = AE / float64(nRows)
-	MAPE = APE / float64(nRows)
+	MAE = AE / sumWeights
+	MAPE = APE / sumWeights
 
 	return Metrics{
 		R2:   R2,
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"math"
+	"slices"
+)
+
+// PinballLoss is the mean quantile (pinball) loss of predictions of the given quantile of yTrue.
+// Under-predictions cost quantile per unit and over-predictions cost 1 - quantile, so it reduces to MAE / 2 at the median.
+func PinballLoss(yTrue, yPred []float64, quantile float64) float64 {
+	loss := 0.0
+	for i := range yTrue {
+		diff := yTrue[i] - yPred[i]
+		loss += math.Max(quantile*diff, (quantile-1)*diff)
+	}
+	return loss / float64(len(yTrue))
+}
+
+// Quantile returns the q-th empirical quantile of values, linearly interpolating between order statistics.
+// values must already be sorted in ascending order; use QuantileUnsorted otherwise.
+func Quantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(values)-1)
+	lo := int(math.Floor(pos))
+	if lo < 0 {
+		return values[0]
+	}
+	if lo >= len(values)-1 {
+		return values[len(values)-1]
+	}
+	frac := pos - float64(lo)
+	return values[lo] + frac*(values[lo+1]-values[lo])
+}
+
+// QuantileUnsorted returns the q-th empirical quantile of values without modifying them.
+func QuantileUnsorted(values []float64, q float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return Quantile(sorted, q)
+}
+
+// OrderStatistic returns the k-th smallest of values, counting from 1, sorting values in place. It returns -Inf for
+// k < 1 and +Inf past the last value, the convention of conformal prediction intervals built from too few scores.
+func OrderStatistic(values []float64, k int) float64 {
+	if k < 1 {
+		return math.Inf(-1)
+	}
+	if k > len(values) {
+		return math.Inf(1)
+	}
+	slices.Sort(values)
+	return values[k-1]
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPinballLoss(t *testing.T) {
+	yTrue := []float64{1, 2, 3}
+	yPred := []float64{2, 2, 1}
+	// An over-prediction by 1 costs 1 - q, an under-prediction by 2 costs 2q
+	assertClose(t, "q=0.25", PinballLoss(yTrue, yPred, 0.25), (0.75+0.5)/3, 1e-15)
+	assertClose(t, "q=0.9", PinballLoss(yTrue, yPred, 0.9), (0.1+1.8)/3, 1e-15)
+	// The mean absolute error is 1
+	assertClose(t, "median", PinballLoss(yTrue, yPred, 0.5), 0.5, 1e-15)
+}
+
+func TestQuantile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	for _, tc := range []struct {
+		q, want float64
+	}{
+		{0, 1},
+		{0.1, 1.4},
+		{0.25, 2},
+		{0.5, 3},
+		{0.9, 4.6},
+		{1, 5},
+		{-0.1, 1},
+		{1.1, 5},
+	} {
+		assertClose(t, "Quantile", Quantile(values, tc.q), tc.want, 1e-12)
+	}
+	if got := Quantile([]float64{7}, 0.3); got != 7 {
+		t.Errorf("quantile of a single value = %v, want 7", got)
+	}
+	if got := Quantile(nil, 0.5); !math.IsNaN(got) {
+		t.Errorf("quantile of no values = %v, want NaN", got)
+	}
+
+	unsorted := []float64{3, 1, 5, 2, 4}
+	assertClose(t, "QuantileUnsorted", QuantileUnsorted(unsorted, 0.9), 4.6, 1e-12)
+	if !slices.Equal(unsorted, []float64{3, 1, 5, 2, 4}) {
+		t.Errorf("QuantileUnsorted modified its input to %v", unsorted)
+	}
+}
+
+func TestOrderStatistic(t *testing.T) {
+	for _, tc := range []struct {
+		k    int
+		want float64
+	}{
+		{-1, math.Inf(-1)},
+		{0, math.Inf(-1)},
+		{1, 1},
+		{2, 2},
+		{4, 5},
+		{5, math.Inf(1)},
+	} {
+		if got := OrderStatistic([]float64{5, 1, 3, 2}, tc.k); got != tc.want {
+			t.Errorf("OrderStatistic(k=%d) = %v, want %v", tc.k, got, tc.want)
+		}
+	}
+	if got := OrderStatistic(nil, 1); !math.IsInf(got, 1) {
+		t.Errorf("OrderStatistic of no values = %v, want +Inf", got)
+	}
+}